@@ -0,0 +1,152 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	log "github.com/xaanit/simple-logger"
+	"testing"
+)
+
+func TestBuildE(t *testing.T) {
+	_, err := log.ConsoleLoggerBuilder().BuildE()
+	buildErr, ok := err.(*log.BuildError)
+	if !ok {
+		t.Fatalf("BuildE returned [%v] not a *BuildError", err)
+	}
+	if len(buildErr.Problems) != 2 {
+		t.Fatalf("BuildE reported %v problems not 2: %v", len(buildErr.Problems), buildErr)
+	}
+
+	builder := log.ConsoleLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.AddPadding(log.Padding(42))
+	if _, err := builder.BuildE(); err == nil {
+		t.Fatalf("BuildE accepted an unknown padding")
+	}
+
+	builder = log.ConsoleLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.AddPadding(log.DatePadding)
+	if _, err := builder.BuildE(); err == nil {
+		t.Fatalf("BuildE accepted DatePadding without a date column")
+	}
+
+	_, _ = builder.AddPaddedColumn(3, "user", func(context log.Context) string {
+		return context.FormatDate(log.Day) + context.Info.(map[string]interface{})["user"].(string)
+	}, log.DatePadding)
+	if _, err := builder.BuildE(); err != nil {
+		t.Fatalf("BuildE rejected DatePadding with a date column: %v", err)
+	}
+
+	builder = log.ConsoleLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, []uint{0})
+	if _, err := builder.BuildE(); err == nil {
+		t.Fatalf("BuildE accepted TimestampPadding without the timestamp column")
+	}
+
+	builder = log.ConsoleLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, nil)
+	logger, err := builder.BuildE()
+	if err != nil || logger == nil {
+		t.Fatalf("BuildE failed on the defaults: %v", err)
+	}
+}
+
+func TestNamedColumns(t *testing.T) {
+	builder := log.NewGenericLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, nil)
+
+	if _, err := builder.RemoveColumn(log.TimestampColumn); err != nil {
+		t.Fatalf("RemoveColumn failed: %v", err)
+	}
+	if _, err := builder.MoveColumn(log.MessageColumn, 0); err != nil {
+		t.Fatalf("MoveColumn failed: %v", err)
+	}
+	if _, err := builder.InsertAfter(log.MessageColumn, "separator", func(log.Context) string { return "-" }); err != nil {
+		t.Fatalf("InsertAfter failed: %v", err)
+	}
+	if _, err := builder.RemoveColumn("missing"); err == nil {
+		t.Fatalf("RemoveColumn accepted a missing column")
+	}
+
+	expected := []string{log.MessageColumn, "separator", log.LevelColumn}
+	if len(builder.ColumnNames) != len(expected) || len(builder.Columns) != len(expected) {
+		t.Fatalf("ColumnNames was %v not %v", builder.ColumnNames, expected)
+	}
+	for index, name := range expected {
+		if builder.ColumnNames[index] != name {
+			t.Fatalf("ColumnNames was %v not %v", builder.ColumnNames, expected)
+		}
+	}
+}
+
+func TestNilColumns(t *testing.T) {
+	builder := log.NewGenericLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.AddNamedColumn("nil", nil).AddColumn(nil)
+
+	_, err := builder.BuildE()
+	buildErr, ok := err.(*log.BuildError)
+	if !ok || len(buildErr.Problems) != 2 || buildErr.Problems[0] != "column 3 is nil" {
+		t.Fatalf("BuildE reported [%v] for two nil columns", err)
+	}
+	if _, err := builder.AddColumnByIndex(0, nil); err == nil {
+		t.Fatalf("AddColumnByIndex accepted a nil column")
+	}
+}
+
+func TestNilSink(t *testing.T) {
+	builder := log.SetDefaults(log.SinkLoggerBuilder(nil), nil, nil, nil)
+
+	_, err := builder.BuildE()
+	buildErr, ok := err.(*log.BuildError)
+	if !ok || len(buildErr.Problems) != 1 || buildErr.Problems[0] != "a sink is required" {
+		t.Fatalf("BuildE reported [%v] for a nil sink", err)
+	}
+	if code, err := builder.Build().Log("INFO", "Hello, world!"); code != log.WriteFailed || err == nil {
+		t.Fatalf("logging without a sink returned %v: %v", code, err)
+	}
+}
+
+func TestCloneAndRemoveLevel(t *testing.T) {
+	base := log.ConsoleLoggerBuilder()
+	log.SetDefaults(base, nil, nil, nil)
+
+	clone := base.Clone()
+	clone.RemoveLevel("DEBUG").RemovePadding(log.LevelPadding)
+
+	if !base.HasLevel("DEBUG") {
+		t.Fatalf("removing a level from a clone changed the original")
+	}
+	if clone.HasLevel("DEBUG") {
+		t.Fatalf("RemoveLevel did not remove DEBUG")
+	}
+
+	expected := []string{"ERROR", "FATAL", "INFO", "WARNING"}
+	levels := clone.ListLevels()
+	if len(levels) != len(expected) {
+		t.Fatalf("ListLevels was %v not %v", levels, expected)
+	}
+	for index, name := range expected {
+		if levels[index] != name {
+			t.Fatalf("ListLevels was %v not %v", levels, expected)
+		}
+	}
+}
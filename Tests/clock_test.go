@@ -0,0 +1,73 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	log "github.com/xaanit/simple-logger"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	clock := log.NewFakeClock(time.Date(2020, time.August, 29, 5, 41, 0, 0, time.UTC))
+	var output bytes.Buffer
+	builder := log.ConsoleLoggerBuilderWithWriter(&output)
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.SetClock(clock).SetSampling(1, 0, time.Minute)
+	logger := builder.Build()
+
+	_, _ = logger.Log("INFO", "tick")
+	if code, _ := logger.Log("INFO", "tick"); code != log.Sampled {
+		t.Fatalf("Log returned %v not Sampled within the interval", code)
+	}
+	clock.Advance(time.Minute)
+	if code, err := logger.Log("INFO", "tick"); code != log.Success {
+		t.Fatalf("Log returned %v after the clock moved past the interval: %v", code, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "Saturday August 29, 2020 @ 5:41:00") ||
+		!strings.Contains(lines[1], "Saturday August 29, 2020 @ 5:42:00") {
+		t.Fatalf("the lines were %q", lines)
+	}
+}
+
+func TestFakeClockStepsOncePerEntry(t *testing.T) {
+	clock := log.NewFakeClock(time.Date(2020, time.August, 29, 5, 41, 0, 0, time.UTC))
+	clock.SetStep(time.Second)
+	var output bytes.Buffer
+	builder := log.ConsoleLoggerBuilderWithWriter(&output)
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.SetClock(clock).SetSampling(10, 0, time.Minute)
+	logger := builder.Build()
+
+	_, _ = logger.Log("INFO", "first")
+	_, _ = logger.Log("INFO", "second")
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "5:41:00") || !strings.Contains(lines[1], "5:41:01") {
+		t.Fatalf("the lines were %q", lines)
+	}
+	if now := clock.Now(); now.Second() != 2 {
+		t.Fatalf("the clock was read %v times for 2 entries", now.Second())
+	}
+}
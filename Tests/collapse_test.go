@@ -0,0 +1,49 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	log "github.com/xaanit/simple-logger"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollapsingLogger(t *testing.T) {
+	buffer := &syncBuffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, []log.Padding{log.TimestampPadding, log.LevelPadding}, []uint{0})
+	logger := log.NewCollapsingLogger(builder.Build(), time.Hour)
+
+	for i := 0; i < 3; i++ {
+		_, _ = logger.Log("ERROR", "connection refused")
+	}
+	_, _ = logger.Log("INFO", "connected")
+	_, _ = logger.Flush()
+
+	expected := []string{
+		log.Error() + " | connection refused (x3)",
+		log.Info() + " | connected",
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("CollapsingLogger wrote %q not %q", lines, expected)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	"encoding/json"
+	log "github.com/xaanit/simple-logger"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	yaml := `
+levels:
+  - name: INFO
+    color: cyan
+  - name: AUDIT
+    color: bold magenta
+    severity: 35
+paddings: []
+columns: ["[{level}]", message]
+min_level: INFO
+`
+	toml := `
+paddings = []
+columns = ["[{level}]", "message"]
+min_level = "INFO"
+
+[[levels]]
+name = "INFO"
+color = "cyan"
+
+[[levels]]
+name = "AUDIT"
+color = "bold magenta"
+severity = 35
+`
+	for format, data := range map[string]string{"yaml": yaml, "toml": toml} {
+		config, err := log.ParseConfig([]byte(data), format)
+		if err != nil {
+			t.Fatalf("ParseConfig failed for %v: %v", format, err)
+		}
+
+		_ = os.Setenv(log.EnvLevel, "AUDIT")
+		config.ApplyEnvironment()
+		_ = os.Unsetenv(log.EnvLevel)
+
+		builder, err := config.Builder()
+		if err != nil {
+			t.Fatalf("Builder failed for %v: %v", format, err)
+		}
+
+		logger, err := builder.BuildE()
+		if err != nil {
+			t.Fatalf("BuildE failed for %v: %v", format, err)
+		}
+
+		reconfigurable := logger.(log.ReconfigurableLogger)
+		if reconfigurable.GetMinLevel() != "AUDIT" || reconfigurable.GetSeverities()["INFO"] != log.InfoSeverity ||
+			reconfigurable.GetSeverities()["AUDIT"] != 35 {
+			t.Fatalf("%v config was loaded as min level %v with severities %v", format, reconfigurable.GetMinLevel(), reconfigurable.GetSeverities())
+		}
+		if len(logger.GetLevels()) != 2 || len(logger.GetColumns()) != 2 || len(logger.GetPaddings()) != 0 {
+			t.Fatalf("%v config was loaded with the wrong levels, columns or paddings", format)
+		}
+	}
+}
+
+func TestConfigLevelWithoutSeverity(t *testing.T) {
+	config, err := log.ParseConfig([]byte(`{"levels": [{"name": "INFO"}, {"name": "AUDIT"}], "min_level": "INFO"}`), "json")
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	builder, err := config.Builder()
+	if err != nil {
+		t.Fatalf("Builder failed: %v", err)
+	}
+	logger, err := builder.BuildE()
+	if err != nil {
+		t.Fatalf("BuildE failed: %v", err)
+	}
+
+	reconfigurable := logger.(log.ReconfigurableLogger)
+	if _, ok := reconfigurable.GetSeverities()["AUDIT"]; ok || !reconfigurable.IsLevelEnabled("AUDIT") {
+		t.Fatalf("AUDIT was given the severities %v", reconfigurable.GetSeverities())
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.SetEncoder(log.JSONEncoder)
+
+	_, _ = builder.Build().LogWithExtraInfo("INFO", "Hello, world!", map[string]interface{}{"user": "xaanit"})
+
+	entry := make(map[string]interface{})
+	if err := json.Unmarshal(buffer.Bytes(), &entry); err != nil {
+		t.Fatalf("JSONEncoder wrote [%v] which isn't JSON: %v", buffer.String(), err)
+	}
+	if entry["level"] != "INFO" || entry["message"] != "Hello, world!" || entry["user"] != "xaanit" {
+		t.Fatalf("JSONEncoder wrote [%v]", buffer.String())
+	}
+}
+
+func TestConfigWatcherReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "simple-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "logging.json")
+	if err := ioutil.WriteFile(path, []byte(`{"min_level": "WARNING"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := &bytes.Buffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, nil, nil)
+	logger := builder.Build().(log.ReconfigurableLogger)
+
+	watcher, err := log.WatchConfig(path, time.Hour, logger)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if logger.GetMinLevel() != "WARNING" {
+		t.Fatalf("Reload set the minimum level to [%v] not WARNING", logger.GetMinLevel())
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"min_level": "TRACE"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := watcher.Reload(); err == nil {
+		t.Fatalf("Reload accepted a minimum level that isn't a level")
+	}
+	if !strings.Contains(buffer.String(), "failed to reload") {
+		t.Fatalf("the failed reload wasn't logged, the output was [%v]", buffer.String())
+	}
+}
+
+func TestConfigWatcherDetectsChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "simple-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "logging.json")
+	if err := ioutil.WriteFile(path, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := &syncBuffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.AddRedactor(log.EmailRedactor)
+	logger := builder.Build().(log.ReconfigurableLogger)
+
+	waitFor := func(level string) {
+		for start := time.Now(); logger.GetMinLevel() != level; time.Sleep(5 * time.Millisecond) {
+			if time.Since(start) > 2*time.Second {
+				t.Fatalf("the minimum level was [%v] not %v", logger.GetMinLevel(), level)
+			}
+		}
+	}
+
+	watcher, err := log.WatchConfig(path, 10*time.Millisecond, logger)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	if err := ioutil.WriteFile(path, []byte(`{"min_level": "WARNING"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("WARNING")
+	watcher.Close()
+
+	_, _ = logger.Log("ERROR", "mail jacob@example.com")
+	if output := buffer.String(); strings.Contains(output, "jacob@example.com") || !strings.Contains(output, log.RedactedText) {
+		t.Fatalf("the redactor didn't survive the reload, the output was [%v]", output)
+	}
+}
+
+func TestConfigWatcherReloadsEveryLoggerOrNone(t *testing.T) {
+	dir, err := ioutil.TempDir("", "simple-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "logging.json")
+	if err := ioutil.WriteFile(path, []byte(`{"min_level": "WARNING"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	first := log.ConsoleLoggerBuilderWithWriter(ioutil.Discard)
+	log.SetDefaults(first, nil, nil, nil)
+	second := log.ConsoleLoggerBuilderWithWriter(ioutil.Discard)
+	log.SetDefaults(second, nil, nil, nil)
+	// The file doesn't have AUDIT, so the hook can't be kept for the second Logger.
+	second.AddLevel("AUDIT", func() string { return "AUDIT" }).AddHook(log.HookFuncs{}, "AUDIT")
+	loggers := []log.ReconfigurableLogger{
+		first.Build().(log.ReconfigurableLogger),
+		second.Build().(log.ReconfigurableLogger),
+	}
+
+	watcher, err := log.WatchConfig(path, 0, loggers...)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Reload(); err == nil {
+		t.Fatalf("Reload accepted a configuration the second logger can't use")
+	}
+	if loggers[0].GetMinLevel() != "" {
+		t.Fatalf("the first logger was reconfigured to [%v] even though the reload failed", loggers[0].GetMinLevel())
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	log "github.com/xaanit/simple-logger"
+	"testing"
+)
+
+type requestIDKey struct{}
+
+func TestLogCtx(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.SetEncoder(log.JSONEncoder)
+	builder.AddExtractor(func(ctx context.Context) map[string]interface{} {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			return map[string]interface{}{"request_id": id}
+		}
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "abc123")
+	ctx = log.WithFields(ctx, map[string]interface{}{"user": "xaanit"})
+	ctx = log.WithLogger(ctx, builder.Build())
+
+	logger := log.FromContext(ctx).(log.ContextLogger)
+	_, _ = logger.LogCtxWithExtraInfo(ctx, "INFO", "Hello, world!", map[string]interface{}{"user": "jacob"})
+
+	entry := make(map[string]interface{})
+	if err := json.Unmarshal(buffer.Bytes(), &entry); err != nil {
+		t.Fatalf("JSONEncoder wrote [%v]: %v", buffer.String(), err)
+	}
+	if entry["request_id"] != "abc123" || entry["user"] != "jacob" {
+		t.Fatalf("LogCtx wrote [%v]", buffer.String())
+	}
+}
+
+func TestTraceColumns(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, []log.Padding{log.TimestampPadding, log.LevelPadding}, []uint{0})
+	builder.AddExtractor(log.SpanExtractor)
+	trace, _ := log.LookupColumn(log.TraceColumn)
+	builder.AddNamedColumnByIndex(0, log.TraceColumn, trace)
+	builder.AddNamedColumnByIndex(1, log.SpanColumn, log.SpanIDColumnFunc)
+	logger := builder.Build().(log.ContextLogger)
+
+	ctx := log.ContextWithSpan(context.Background(), log.NewSpanContext("4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"))
+	_, _ = logger.LogCtx(ctx, "INFO", "Hello, world!")
+
+	expected := "4bf92f3577b34da6a3ce929d0e0e4736 | 00f067aa0ba902b7 | " + log.Info() + " | Hello, world!\n"
+	if buffer.String() != expected {
+		t.Fatalf("the trace columns wrote [%v] not [%v]", buffer.String(), expected)
+	}
+}
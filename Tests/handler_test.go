@@ -0,0 +1,83 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"encoding/json"
+	log "github.com/xaanit/simple-logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLevelHandler(t *testing.T) {
+	builder := log.ConsoleLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, nil)
+	logger := builder.Build()
+
+	handler := log.NewLevelHandler()
+	if err := handler.Register("api", logger); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	body := strings.NewReader(`{"min_level": "WARNING", "enabled_levels": ["INFO", "WARNING", "FATAL"]}`)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/api", body))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("PUT returned %v: %v", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api", nil))
+	status := log.LevelStatus{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &status); err != nil {
+		t.Fatalf("GET returned [%v]: %v", recorder.Body.String(), err)
+	}
+	if status.MinLevel != "WARNING" || strings.Join(status.EnabledLevels, ",") != "FATAL,INFO,WARNING" ||
+		strings.Join(status.DisabledLevels, ",") != "DEBUG,ERROR" {
+		t.Fatalf("GET returned %+v", status)
+	}
+
+	// Putting back what GET returned with a lower minimum level brings back the levels that were only below it.
+	status.MinLevel = "DEBUG"
+	update, _ := json.Marshal(status)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/api", strings.NewReader(string(update))))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("PUT of a GET response returned %v: %v", recorder.Code, recorder.Body.String())
+	}
+	reconfigurable := logger.(log.ReconfigurableLogger)
+	if !reconfigurable.IsLevelEnabled("INFO") || reconfigurable.IsLevelEnabled("ERROR") {
+		t.Fatalf("PUT of a GET response changed which levels are disabled")
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/api", strings.NewReader(`{"min_level": "TRACE"}`)))
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("PUT of an invalid level returned %v", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("GET of a missing logger returned %v", recorder.Code)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	log "github.com/xaanit/simple-logger"
+	"strings"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, []log.Padding{log.TimestampPadding, log.LevelPadding}, []uint{0})
+
+	written := 0
+	builder.AddHook(log.HookFuncs{
+		BeforeFunc: func(context *log.Context) bool {
+			context.Message = strings.ToUpper(context.Message)
+			return true
+		},
+		AfterFunc: func(context log.Context, line string, err error) {
+			written++
+		},
+	})
+	builder.AddHook(log.HookFuncs{
+		BeforeFunc: func(context *log.Context) bool { return false },
+	}, "DEBUG")
+	logger := builder.Build()
+
+	if status, _ := logger.Log("DEBUG", "dropped"); status != log.Aborted {
+		t.Fatalf("the aborted DEBUG returned %v", status)
+	}
+	if status, _ := logger.Log("INFO", "hello"); status != log.Success {
+		t.Fatalf("INFO returned %v", status)
+	}
+
+	if buffer.String() != log.Info()+" | HELLO\n" || written != 1 {
+		t.Fatalf("the hooks wrote [%v] and were called after %v writes", buffer.String(), written)
+	}
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	log "github.com/xaanit/simple-logger"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHTTPSink(t *testing.T) {
+	var mutex sync.Mutex
+	requests := 0
+	batches := make([][]string, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		requests++
+		if requests == 1 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if request.Header.Get("Authorization") != "Bearer secret" || request.Header.Get("Content-Encoding") != "gzip" {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		reader, err := gzip.NewReader(request.Body)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := ioutil.ReadAll(reader)
+		batches = append(batches, strings.Split(strings.TrimSuffix(string(body), "\n"), "\n"))
+	}))
+	defer server.Close()
+
+	errs := make([]error, 0)
+	builder := log.HTTPLoggerBuilder(log.HTTPConfig{
+		URL:           server.URL,
+		Headers:       map[string]string{"Authorization": "Bearer secret"},
+		BatchSize:     2,
+		BatchInterval: time.Hour,
+		Gzip:          true,
+		RetryBackoff:  time.Millisecond,
+		OnError:       func(err error) { errs = append(errs, err) },
+	})
+	log.SetDefaults(builder, nil, nil, nil)
+	logger := builder.Build().(*log.SinkLogger)
+
+	for _, message := range []string{"first", "second", "third"} {
+		if code, err := logger.Log("INFO", message); code != log.Success {
+			t.Fatalf("Log returned %v: %v", code, err)
+		}
+	}
+	_ = logger.Close()
+
+	if len(errs) != 0 {
+		t.Fatalf("shipping failed: %v", errs)
+	}
+	if requests != 3 || len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("%v requests were made for the batches %v", requests, batches)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(batches[1][0]), &entry); err != nil || entry["message"] != "third" {
+		t.Fatalf("the last line was %v: %v", batches[1][0], err)
+	}
+	if code, _ := logger.Log("INFO", "closed"); code != log.WriteFailed {
+		t.Fatalf("Log returned %v after Close not WriteFailed", code)
+	}
+}
+
+func TestHTTPSinkWithSlowServer(t *testing.T) {
+	var mutex sync.Mutex
+	received := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		body, _ := ioutil.ReadAll(request.Body)
+
+		mutex.Lock()
+		received += strings.Count(string(body), "\n")
+		mutex.Unlock()
+	}))
+	defer server.Close()
+
+	builder := log.HTTPLoggerBuilder(log.HTTPConfig{URL: server.URL, BatchSize: 1, BatchInterval: time.Millisecond})
+	log.SetDefaults(builder, nil, nil, nil)
+	logger := builder.Build().(*log.SinkLogger)
+
+	logged := make(chan struct{})
+	go func() {
+		defer close(logged)
+		for i := 0; i < 20; i++ {
+			_, _ = logger.Log("INFO", "slow")
+		}
+	}()
+
+	select {
+	case <-logged:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatalf("logging waited for the server")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		_ = logger.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Close never returned")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if received != 20 {
+		t.Fatalf("the server received %v lines not 20", received)
+	}
+}
+
+func TestHTTPSinkWithStuckServer(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-release:
+		case <-request.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	var mutex sync.Mutex
+	errs := 0
+	sink := log.NewHTTPSink(log.HTTPConfig{
+		URL:              server.URL,
+		BatchSize:        1,
+		BatchInterval:    time.Hour,
+		MaxRetries:       -1,
+		MaxQueuedBatches: 2,
+		CloseTimeout:     50 * time.Millisecond,
+		OnError: func(err error) {
+			mutex.Lock()
+			errs++
+			mutex.Unlock()
+		},
+	})
+
+	failed := 0
+	for i := 0; i < 10; i++ {
+		if err := sink.Write(log.Context{}, "{}"); err != nil {
+			failed++
+		}
+	}
+	if failed == 0 || sink.Stats().Queued > 2 {
+		t.Fatalf("the queue went past its limit, Stats were %+v", sink.Stats())
+	}
+
+	start := time.Now()
+	if err := sink.Close(); err == nil {
+		t.Fatalf("Close didn't report giving up")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Close took %v", elapsed)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if stats := sink.Stats(); stats.Dropped != 10 || stats.Shipped != 0 || errs == 0 {
+		t.Fatalf("Stats were %+v after Close with %v errors reported", stats, errs)
+	}
+}
+
+func TestHTTPSinkWithDiskBuffer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "http")
+	if err != nil {
+		t.Fatalf("could not create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var mutex sync.Mutex
+	up := false
+	received := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if !up {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(request.Body)
+		received = append(received, strings.TrimSuffix(string(body), "\n"))
+	}))
+	defer server.Close()
+
+	open := func() *log.HTTPSink {
+		buffer, err := log.NewDiskBuffer(log.DiskBufferConfig{Dir: dir})
+		if err != nil {
+			t.Fatalf("NewDiskBuffer failed: %v", err)
+		}
+		return log.NewHTTPSink(log.HTTPConfig{URL: server.URL, BatchSize: 1, BatchInterval: time.Millisecond,
+			MaxRetries: -1, CloseTimeout: 50 * time.Millisecond, Buffer: buffer})
+	}
+
+	sink := open()
+	for _, line := range []string{`{"n":1}`, `{"n":2}`} {
+		if err := sink.Write(log.Context{}, line); err != nil {
+			t.Fatalf("Write failed while the endpoint was down: %v", err)
+		}
+	}
+	_ = sink.Close()
+	if stats := sink.Stats(); stats.Dropped != 0 || stats.Shipped != 0 {
+		t.Fatalf("Stats were %+v while the endpoint was down", stats)
+	}
+
+	mutex.Lock()
+	up = true
+	mutex.Unlock()
+
+	sink = open()
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if strings.Join(received, ",") != `{"n":1},{"n":2}` {
+		t.Fatalf("received %v after the restart", received)
+	}
+}
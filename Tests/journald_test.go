@@ -0,0 +1,118 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	log "github.com/xaanit/simple-logger"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestJournalLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatalf("could not create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "socket")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unix datagram sockets are not supported: %v", err)
+	}
+	defer listener.Close()
+
+	builder := log.JournalLoggerBuilder(log.JournalConfig{Socket: socket, Identifier: "app"})
+	log.SetDefaults(builder, nil, nil, nil)
+	logger := builder.Build().(*log.SinkLogger)
+	defer logger.Close()
+
+	info := map[string]interface{}{"user-id": 7, "stack": "first\nsecond", "message": "shadowed", strings.Repeat("k", 65): 1}
+	if code, err := logger.LogWithExtraInfo("WARNING", "Hello, journal", info); code != log.Success {
+		t.Fatalf("LogWithExtraInfo returned %v: %v", code, err)
+	}
+
+	buffer := make([]byte, 4096)
+	_ = listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := listener.Read(buffer)
+	if err != nil {
+		t.Fatalf("nothing was received: %v", err)
+	}
+
+	entry := buffer[:n]
+	for _, field := range []string{"MESSAGE=Hello, journal\n", "PRIORITY=4\n", "SYSLOG_IDENTIFIER=app\n", "USER_ID=7\n",
+		"FIELD_MESSAGE=shadowed\n"} {
+		if !bytes.Contains(entry, []byte(field)) {
+			t.Fatalf("%q is missing from %q", field, entry)
+		}
+	}
+	if !bytes.Contains(entry, []byte("STACK\n\x0c\x00\x00\x00\x00\x00\x00\x00first\nsecond\n")) {
+		t.Fatalf("STACK was not sent with its length in %q", entry)
+	}
+	if bytes.Count(entry, []byte("MESSAGE=")) != 2 || bytes.Contains(entry, []byte("KKKK")) {
+		t.Fatalf("reserved or too long field names were sent in %q", entry)
+	}
+
+	large := strings.Repeat("x", 1<<20)
+	if code, err := logger.Log("INFO", large); code != log.Success {
+		t.Fatalf("Log returned %v for a large entry: %v", code, err)
+	}
+	oob := make([]byte, syscall.CmsgSpace(4))
+	_ = listener.SetReadDeadline(time.Now().Add(time.Second))
+	_, oobn, _, _, err := listener.ReadMsgUnix(buffer, oob)
+	if err != nil {
+		t.Fatalf("nothing was received for the large entry: %v", err)
+	}
+	messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("the large entry wasn't passed as a file: %v", err)
+	}
+	fds, err := syscall.ParseUnixRights(&messages[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("the large entry wasn't passed as a file: %v", err)
+	}
+	file := os.NewFile(uintptr(fds[0]), "journal")
+	defer file.Close()
+	_, _ = file.Seek(0, 0)
+	contents, _ := ioutil.ReadAll(file)
+	if !bytes.Contains(contents, []byte("MESSAGE="+large+"\n")) {
+		t.Fatalf("the file passed held %v bytes without the message", len(contents))
+	}
+
+	var fallback bytes.Buffer
+	builder = log.JournalLoggerBuilder(log.JournalConfig{Socket: filepath.Join(dir, "missing"), Fallback: &fallback})
+	log.SetDefaults(builder, nil, nil, nil)
+	if code, err := builder.Build().Log("INFO", "Hello, console"); code != log.Success {
+		t.Fatalf("Log returned %v: %v", code, err)
+	}
+	if !strings.Contains(fallback.String(), "Hello, console") {
+		t.Fatalf("the fallback got %q", fallback.String())
+	}
+}
@@ -22,81 +22,21 @@ package Tests
 import (
 	"fmt"
 	log "github.com/xaanit/simple-logger"
+	"github.com/xaanit/simple-logger/logtest"
 	"testing"
-	"time"
 )
 
-type testLogger struct {
-	levels   map[string]func() string
-	paddings []log.Padding
-	columns  []log.Column
-	str      string
-}
-
-func (t *testLogger) GetLevels() map[string]func() string {
-	return t.levels
-}
-
-func (t testLogger) GetPaddings() []log.Padding {
-	return t.paddings
-}
-
-func (t testLogger) GetColumns() []log.Column {
-	return t.columns
-}
-
-func (c *testLogger) createContext(level, message string) log.Context {
-	return log.Context{
-		Message: message,
-		Time:    time.Now(),
-		Level:   level,
-		Logger:  c,
-	}
-}
-
-func (c *testLogger) Log(level, message string) (int, error) {
-	context := c.createContext(level, message)
-	format := ""
-	for index, column := range c.columns {
-		pad := ""
-		if index != 0 {
-			pad = " "
-		}
-
-		end := ""
-		if index != len(c.columns)-1 {
-			end = " |"
-		}
-
-		format += fmt.Sprintf("%v%v%v", pad, column(context), end)
-	}
-
-	c.str = format
-
-	return log.Success, nil
-}
-
-func (t *testLogger) LogWithExtraInfo(level, message string, info interface{}) (int, error) {
-	return t.Log(level, message)
-}
-
 func TestLogger(t *testing.T) {
 	builder := log.NewGenericLoggerBuilder()
 	log.SetDefaults(builder, nil, nil, []uint{0})
-	paddings := make([]log.Padding, 0)
-	for padding := range builder.Paddings {
-		paddings = append(paddings, padding)
-	}
-	logger := &testLogger{
-		levels:   builder.Levels,
-		paddings: paddings,
-		columns:  builder.Columns,
-		str:      "",
+	logger, err := logtest.NewRecordingLogger(builder)
+	if err != nil {
+		t.Fatalf("NewRecordingLogger failed: %v", err)
 	}
 
 	_, _ = logger.Log("INFO", "Hello, world!")
 	expected := fmt.Sprintf("%v    | Hello, world!", log.Info())
-	if logger.str != expected {
-		t.Fatalf("Logger.str was [%v] not [%v]", logger.str, expected)
+	if line := logger.Entries()[0].Line; line != expected {
+		t.Fatalf("the line was [%v] not [%v]", line, expected)
 	}
 }
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	log "github.com/xaanit/simple-logger"
+	"github.com/xaanit/simple-logger/logtest"
+	"testing"
+	"time"
+)
+
+func TestRecordingLogger(t *testing.T) {
+	builder := log.ConsoleLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, nil)
+	logger, err := logtest.NewRecordingLogger(builder)
+	if err != nil {
+		t.Fatalf("NewRecordingLogger failed: %v", err)
+	}
+
+	_, _ = logger.Log("INFO", "server started")
+	logger.Clock().Advance(90 * time.Second)
+	_, _ = logger.LogWithExtraInfo("ERROR", "request failed", map[string]interface{}{"status": 500})
+
+	logger.AssertLogged(t, "ERROR", "failed")
+	logger.AssertLogged(t, "", "started")
+	logger.AssertNotLogged(t, "WARNING", "")
+	if entries := logger.Entries(); len(entries) != 2 || entries[1].Fields["status"] != 500 ||
+		!entries[1].Time.Equal(logtest.DefaultTime.Add(90*time.Second)) {
+		t.Fatalf("Entries returned %+v", entries)
+	}
+	logger.AssertGolden(t, "testdata/recording.golden")
+
+	if _, err := logtest.NewRecordingLogger(&customBuilder{builder}); err == nil {
+		t.Fatalf("NewRecordingLogger accepted a builder from outside the package")
+	}
+}
+
+type customBuilder struct {
+	log.LoggerBuilder
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bufio"
+	log "github.com/xaanit/simple-logger"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNetworkSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	dir, err := ioutil.TempDir("", "network")
+	if err != nil {
+		t.Fatalf("could not create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	buffer, err := log.NewFileBuffer(filepath.Join(dir, "buffer"), 1)
+	if err != nil {
+		t.Fatalf("NewFileBuffer failed: %v", err)
+	}
+	sink := log.NewNetworkSink(log.NetworkConfig{Network: "tcp", Address: address, MinBackoff: time.Millisecond,
+		Buffer: buffer})
+	builder := log.SinkLoggerBuilder(sink)
+	builder.AddLevel("INFO", func() string { return "INFO" }).
+		AddColumn(func(context log.Context) string { return context.Message })
+	logger := builder.Build()
+
+	if code, err := logger.Log("INFO", "first"); code != log.Success {
+		t.Fatalf("Log returned %v while the endpoint was down: %v", code, err)
+	}
+	if code, _ := logger.Log("INFO", "second"); code != log.WriteFailed {
+		t.Fatalf("Log returned %v not WriteFailed with a full buffer", code)
+	}
+	if stats := sink.Stats(); stats.Buffered != 1 || stats.Dropped != 1 || stats.Written != 0 {
+		t.Fatalf("Stats were %+v while the endpoint was down", stats)
+	}
+
+	listener, err = net.Listen("tcp", address)
+	if err != nil {
+		t.Skipf("could not listen on %v again: %v", address, err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			received <- scanner.Text()
+		}
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	if code, err := logger.Log("INFO", "third"); code != log.Success {
+		t.Fatalf("Log returned %v after the endpoint came back: %v", code, err)
+	}
+
+	lines := make([]string, 0, 2)
+	for len(lines) < 2 {
+		select {
+		case line := <-received:
+			lines = append(lines, line)
+		case <-time.After(time.Second):
+			t.Fatalf("only received %v", lines)
+		}
+	}
+	if strings.Join(lines, ",") != "first,third" {
+		t.Fatalf("received %v not the buffered line first", lines)
+	}
+	if stats := sink.Stats(); stats.Buffered != 0 || stats.Written != 2 {
+		t.Fatalf("Stats were %+v after the endpoint came back", stats)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("closing the sink again failed: %v", err)
+	}
+}
+
+func TestFileBufferRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "network")
+	if err != nil {
+		t.Fatalf("could not create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "buffer")
+
+	buffer, err := log.NewFileBuffer(path, 10)
+	if err != nil {
+		t.Fatalf("NewFileBuffer failed: %v", err)
+	}
+	for _, line := range []string{"first", "second", "third"} {
+		if err := buffer.Push(line); err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+	}
+	if err := buffer.Pop(); err != nil {
+		t.Fatalf("Pop failed: %v", err)
+	}
+	_ = buffer.Close()
+
+	// Half a line, as if the process stopped while writing it.
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("could not open the buffer file: %v", err)
+	}
+	_, _ = file.WriteString(`"fou`)
+	_ = file.Close()
+
+	buffer, err = log.NewFileBuffer(path, 10)
+	if err != nil {
+		t.Fatalf("NewFileBuffer failed with a torn line: %v", err)
+	}
+	if line, _ := buffer.Peek(); buffer.Len() != 2 || line != "second" {
+		t.Fatalf("reloaded %v lines starting with [%v] not the 2 that weren't popped", buffer.Len(), line)
+	}
+	if err := buffer.Push("fourth"); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	_ = buffer.Close()
+
+	buffer, err = log.NewFileBuffer(path, 2)
+	if err != nil {
+		t.Fatalf("NewFileBuffer failed: %v", err)
+	}
+	defer buffer.Close()
+	if line, _ := buffer.Peek(); buffer.Len() != 2 || line != "second" {
+		t.Fatalf("reloaded %v lines starting with [%v] with a capacity of 2", buffer.Len(), line)
+	}
+}
+
+func TestNetworkSinkFlushesInBackground(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	sink := log.NewNetworkSink(log.NetworkConfig{Network: "tcp", Address: address, MinBackoff: time.Millisecond,
+		FlushInterval: 5 * time.Millisecond})
+	defer sink.Close()
+	if err := sink.Write(log.Context{}, "buffered"); err != nil {
+		t.Fatalf("Write failed while the endpoint was down: %v", err)
+	}
+
+	listener, err = net.Listen("tcp", address)
+	if err != nil {
+		t.Skipf("could not listen on %v again: %v", address, err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		if scanner.Scan() {
+			received <- scanner.Text()
+		}
+	}()
+
+	select {
+	case line := <-received:
+		if line != "buffered" {
+			t.Fatalf("received [%v] not the buffered line", line)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the buffered line was never sent without another Write")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"encoding/json"
+	log "github.com/xaanit/simple-logger"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLokiAndElasticsearchPayloads(t *testing.T) {
+	bodies := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := ioutil.ReadAll(request.Body)
+		bodies[request.URL.Path] = string(body)
+	}))
+	defer server.Close()
+
+	loki := log.LokiLoggerBuilder(log.HTTPConfig{URL: server.URL + "/loki/api/v1/push", BatchInterval: time.Hour},
+		"service")
+	log.SetDefaults(loki, nil, nil, nil)
+	logger := loki.Build().(*log.SinkLogger)
+	service := map[string]interface{}{"service": "api"}
+	_, _ = logger.LogWithExtraInfo("INFO", "first", service)
+	_, _ = logger.LogWithExtraInfo("INFO", "second", service)
+	_, _ = logger.Log("ERROR", "third")
+	_ = logger.Close()
+
+	var push struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal([]byte(bodies["/loki/api/v1/push"]), &push); err != nil {
+		t.Fatalf("the Loki payload was %q: %v", bodies["/loki/api/v1/push"], err)
+	}
+	if len(push.Streams) != 2 || push.Streams[0].Stream["service"] != "api" || push.Streams[0].Stream["level"] != "INFO" ||
+		len(push.Streams[0].Values) != 2 || push.Streams[1].Stream["level"] != "ERROR" {
+		t.Fatalf("the Loki payload was %+v", push)
+	}
+	if line := push.Streams[0].Values[1][1]; !strings.HasSuffix(line, "| second") || strings.Contains(line, "\x1b") {
+		t.Fatalf("the Loki line was %q", line)
+	}
+
+	if _, err := log.ElasticsearchLoggerBuilder(log.HTTPConfig{URL: server.URL}, ""); err == nil {
+		t.Fatalf("ElasticsearchLoggerBuilder accepted an empty index")
+	}
+	elastic, err := log.ElasticsearchLoggerBuilder(log.HTTPConfig{URL: server.URL + "/_bulk", BatchInterval: time.Hour}, "logs")
+	if err != nil {
+		t.Fatalf("ElasticsearchLoggerBuilder failed: %v", err)
+	}
+	log.SetDefaults(elastic, nil, nil, nil)
+	logger = elastic.Build().(*log.SinkLogger)
+	_, _ = logger.Log("WARNING", "Hello, elastic")
+	_ = logger.Close()
+
+	lines := strings.Split(strings.TrimSuffix(bodies["/_bulk"], "\n"), "\n")
+	if len(lines) != 2 || lines[0] != `{"index":{"_index":"logs"}}` || !strings.Contains(lines[1], `"message":"Hello, elastic"`) {
+		t.Fatalf("the bulk payload was %q", bodies["/_bulk"])
+	}
+}
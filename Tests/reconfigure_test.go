@@ -0,0 +1,65 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	log "github.com/xaanit/simple-logger"
+	"testing"
+)
+
+func TestReconfigure(t *testing.T) {
+	builder := log.ConsoleLoggerBuilder()
+	log.SetDefaults(builder, nil, nil, nil)
+	logger := builder.Build().(log.ReconfigurableLogger)
+
+	err := logger.Reconfigure(func(builder log.LoggerBuilder) {
+		builder.SetMinLevel("WARNING")
+	})
+	if err != nil {
+		t.Fatalf("Reconfigure failed: %v", err)
+	}
+
+	if status, _ := logger.Log("DEBUG", "Hello, world!"); status != log.BelowMinLevel {
+		t.Fatalf("Log returned %v not BelowMinLevel", status)
+	}
+
+	err = logger.Reconfigure(func(builder log.LoggerBuilder) {
+		builder.AddLevel("AUDIT", func() string { return "AUDIT" })
+	})
+	if err != nil || !logger.IsLevelEnabled("AUDIT") {
+		t.Fatalf("a level without a severity was skipped by the minimum level: %v", err)
+	}
+
+	err = logger.Reconfigure(func(builder log.LoggerBuilder) {
+		builder.SetMinLevel("TRACE")
+	})
+	if err == nil {
+		t.Fatalf("Reconfigure accepted a minimum level that isn't a level")
+	}
+	err = logger.Reconfigure(func(builder log.LoggerBuilder) {
+		builder.SetMinLevel("AUDIT")
+	})
+	if err == nil || !logger.IsLevelEnabled("WARNING") || logger.IsLevelEnabled("DEBUG") {
+		t.Fatalf("Reconfigure accepted a minimum level without a severity")
+	}
+	if logger.GetMinLevel() != "WARNING" {
+		t.Fatalf("a failed Reconfigure changed the minimum level to [%v]", logger.GetMinLevel())
+	}
+}
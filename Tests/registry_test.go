@@ -0,0 +1,139 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	log "github.com/xaanit/simple-logger"
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	root := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(root, nil, []log.Padding{log.TimestampPadding}, []uint{0})
+	root.AddColumnByIndex(0, func(context log.Context) string { return context.Name })
+
+	registry, err := log.NewRegistry(root)
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+
+	auth := registry.Get("api.auth").(log.ReconfigurableLogger)
+	err = registry.Configure("api", func(builder log.LoggerBuilder) {
+		builder.SetMinLevel("WARNING")
+	})
+	if err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	if auth.GetMinLevel() != "WARNING" {
+		t.Fatalf("Configure didn't reconfigure api.auth, its minimum level is [%v]", auth.GetMinLevel())
+	}
+	if stats := registry.Get("apistats").(log.ReconfigurableLogger); stats.GetMinLevel() != "" {
+		t.Fatalf("Configure of api reconfigured apistats")
+	}
+
+	err = registry.Configure("api", func(builder log.LoggerBuilder) {
+		builder.SetMinLevel("TRACE")
+	})
+	if err == nil {
+		t.Fatalf("Configure accepted a minimum level that isn't a level")
+	}
+	if users := registry.Get("api.users").(log.ReconfigurableLogger); users.GetMinLevel() != "WARNING" {
+		t.Fatalf("a rejected configuration was kept, api.users has the minimum level [%v]", users.GetMinLevel())
+	}
+	err = registry.Configure("api", func(builder log.LoggerBuilder) {
+		registry.Names()
+		builder.SetMinLevel("ERROR")
+	})
+	if err != nil || auth.GetMinLevel() != "ERROR" {
+		t.Fatalf("Configure failed after a rejected configuration: %v", err)
+	}
+
+	_, _ = auth.Log("ERROR", "Hello, world!")
+	if !strings.HasPrefix(buffer.String(), "api.auth |") {
+		t.Fatalf("the name column wrote [%v]", buffer.String())
+	}
+}
+
+func TestRegistryKeepsRuntimeChanges(t *testing.T) {
+	registry, err := log.NewRegistry(log.SetDefaults(log.ConsoleLoggerBuilderWithWriter(&bytes.Buffer{}), nil, nil, nil))
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+
+	auth := registry.Get("api.auth").(log.ReconfigurableLogger)
+	err = auth.Reconfigure(func(builder log.LoggerBuilder) {
+		builder.SetMinLevel("ERROR")
+	})
+	if err != nil {
+		t.Fatalf("Reconfigure failed: %v", err)
+	}
+
+	err = registry.Configure("api", func(builder log.LoggerBuilder) {
+		builder.SetEncoder(log.JSONEncoder)
+	})
+	if err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if auth.GetMinLevel() != "ERROR" {
+		t.Fatalf("Configure of api threw away the minimum level of api.auth, it's now [%v]", auth.GetMinLevel())
+	}
+
+	err = registry.Configure("api.auth", func(builder log.LoggerBuilder) {
+		builder.SetMinLevel("WARNING")
+	})
+	if err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	err = registry.Configure("api", func(builder log.LoggerBuilder) {
+		builder.SetMinLevel("INFO")
+	})
+	if err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if auth.GetMinLevel() != "WARNING" {
+		t.Fatalf("Configure of api won over the one of api.auth, the minimum level is [%v]", auth.GetMinLevel())
+	}
+}
+
+func TestNamedComponents(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, []log.Padding{log.TimestampPadding}, []uint{0})
+	builder.SetName("api")
+	builder.AddNamedColumnByIndex(0, log.NameColumn, log.NameColumnFunc)
+
+	logger := builder.Build().(log.ReconfigurableLogger).Named("auth")
+	if logger.GetName() != "api.auth" {
+		t.Fatalf("Named returned a logger named [%v] not [api.auth]", logger.GetName())
+	}
+
+	_, _ = logger.Log("INFO", "Hello, world!")
+	expected := log.ColorizeName("api.auth") + " |"
+	if !strings.HasPrefix(buffer.String(), expected) {
+		t.Fatalf("NameColumnFunc wrote [%v] not [%v]", buffer.String(), expected)
+	}
+	if log.ColorizeName("api.auth") != log.ColorizeName("api.auth") {
+		t.Fatalf("ColorizeName isn't deterministic")
+	}
+}
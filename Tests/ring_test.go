@@ -0,0 +1,104 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	log "github.com/xaanit/simple-logger"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRingBufferLogger(t *testing.T) {
+	builder := log.RingBufferLoggerBuilder(3)
+	log.SetDefaults(builder, nil, nil, nil)
+	logger := builder.Build().(*log.RingBufferLogger)
+
+	for _, message := range []string{"dropped", "connecting", "retrying"} {
+		_, _ = logger.Log("DEBUG", message)
+	}
+	_, _ = logger.LogWithExtraInfo("WARNING", "slow response", map[string]interface{}{"ms": 900})
+
+	entries := logger.Snapshot()
+	if len(entries) != 3 || entries[0].Message != "connecting" || entries[2].Fields["ms"] != 900 ||
+		!strings.Contains(entries[2].Line, "slow response") {
+		t.Fatalf("Snapshot returned %+v", entries)
+	}
+	if filtered := logger.Filter(log.EntryFilter{Levels: []string{"DEBUG"}, Pattern: regexp.MustCompile("^retry")}); len(filtered) != 1 ||
+		filtered[0].Message != "retrying" {
+		t.Fatalf("Filter returned %+v", filtered)
+	}
+
+	var output bytes.Buffer
+	console := log.ConsoleLoggerBuilderWithWriter(&output)
+	log.SetDefaults(console, nil, nil, nil)
+	if err := logger.DumpOnError(console.Build()); err != nil {
+		t.Fatalf("DumpOnError failed: %v", err)
+	}
+
+	_, _ = logger.Log("ERROR", "request failed")
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[0], "retrying") || !strings.HasSuffix(lines[2], "request failed") {
+		t.Fatalf("the dump was %q", output.String())
+	}
+	if len(logger.Snapshot()) != 0 {
+		t.Fatalf("the entries were kept after dumping them")
+	}
+}
+
+func TestRingBufferDumpKeepsTimes(t *testing.T) {
+	start := time.Date(2020, time.August, 29, 5, 41, 0, 0, time.UTC)
+	clock := log.NewFakeClock(start)
+	builder := log.RingBufferLoggerBuilder(3)
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.SetClock(clock).SetLocation(time.UTC)
+	logger := builder.Build().(*log.RingBufferLogger)
+
+	if err := logger.DumpOnError(logger); err == nil {
+		t.Fatalf("DumpOnError accepted the logger itself")
+	}
+	if err := logger.DumpOnError(builder.Build()); err == nil {
+		t.Fatalf("DumpOnError accepted another logger sharing the entries")
+	}
+	if err := logger.DumpTo(logger.Named("component")); err == nil {
+		t.Fatalf("DumpTo accepted a named logger sharing the entries")
+	}
+
+	var output bytes.Buffer
+	console := log.ConsoleLoggerBuilderWithWriter(&output)
+	console.AddLevel("DEBUG", func() string { return "DEBUG" }).
+		AddLevel("ERROR", func() string { return "ERROR" }).
+		AddColumn(func(context log.Context) string { return context.Time.Format(time.RFC3339) }).
+		SetClock(log.NewFakeClock(start.Add(time.Hour))).
+		SetLocation(time.UTC)
+	if err := logger.DumpOnError(console.Build()); err != nil {
+		t.Fatalf("DumpOnError failed: %v", err)
+	}
+
+	_, _ = logger.Log("DEBUG", "connecting")
+	clock.Advance(time.Minute)
+	_, _ = logger.Log("ERROR", "request failed")
+
+	if dump := output.String(); dump != "2020-08-29T05:41:00Z\n2020-08-29T05:42:00Z\n" {
+		t.Fatalf("the dump was %q", dump)
+	}
+}
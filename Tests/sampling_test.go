@@ -0,0 +1,78 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	log "github.com/xaanit/simple-logger"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A bytes.Buffer that can be written from the goroutine that logs summaries.
+type syncBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.String()
+}
+
+func TestSampling(t *testing.T) {
+	buffer := &syncBuffer{}
+	builder := log.ConsoleLoggerBuilderWithWriter(buffer)
+	log.SetDefaults(builder, nil, []log.Padding{log.TimestampPadding}, []uint{0})
+	builder.SetSampling(2, 0, 50*time.Millisecond)
+	builder.SetRateLimit("ERROR", 0.001, 1)
+	logger := builder.Build()
+
+	for i := 0; i < 5; i++ {
+		status, _ := logger.Log("INFO", "Hello, world!")
+		if (i < 2 && status != log.Success) || (i >= 2 && status != log.Sampled) {
+			t.Fatalf("Log number %v returned %v", i, status)
+		}
+	}
+
+	if status, _ := logger.Log("ERROR", "first"); status != log.Success {
+		t.Fatalf("the first ERROR returned %v", status)
+	}
+	if status, _ := logger.Log("ERROR", "second"); status != log.Sampled {
+		t.Fatalf("the rate limited ERROR returned %v", status)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(buffer.String(), "suppressed 3 similar INFO entries") {
+		if time.Now().After(deadline) {
+			t.Fatalf("no summary was logged, the output was [%v]", buffer.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	log "github.com/xaanit/simple-logger"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogLogger(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	defer listener.Close()
+
+	builder := log.SyslogLoggerBuilder(log.SyslogConfig{
+		Network:    "udp",
+		Address:    listener.LocalAddr().String(),
+		Facility:   16,
+		Hostname:   "host",
+		AppName:    "app",
+		Severities: map[string]int{"AUDIT": log.SyslogNotice},
+	})
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.AddLevel("AUDIT", func() string { return "AUDIT" }).AddLevel("TRACE", func() string { return "TRACE" }).
+		SetSeverity("TRACE", 5)
+	logger := builder.Build().(*log.SinkLogger)
+	defer logger.Close()
+
+	expected := map[string]string{"ERROR": "<131>1 ", "AUDIT": "<133>1 ", "TRACE": "<135>1 "}
+	for _, level := range []string{"ERROR", "AUDIT", "TRACE"} {
+		if code, err := logger.Log(level, "Hello, syslog"); code != log.Success {
+			t.Fatalf("Log returned %v: %v", code, err)
+		}
+
+		buffer := make([]byte, 2048)
+		_ = listener.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := listener.ReadFrom(buffer)
+		if err != nil {
+			t.Fatalf("nothing was received: %v", err)
+		}
+
+		message := string(buffer[:n])
+		if !strings.HasPrefix(message, expected[level]) || !strings.Contains(message, " host app ") ||
+			!strings.HasSuffix(message, "Hello, syslog") || strings.Contains(message, "\x1b") {
+			t.Fatalf("%v was sent as %q", level, message)
+		}
+	}
+}
+
+func TestSyslogOverUnixStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syslog")
+	if err != nil {
+		t.Fatalf("could not create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "socket"))
+	if err != nil {
+		t.Skipf("unix sockets are not supported: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buffer := make([]byte, 2048)
+		n, _ := conn.Read(buffer)
+		received <- string(buffer[:n])
+	}()
+
+	builder := log.SyslogLoggerBuilder(log.SyslogConfig{
+		Network:  "unix",
+		Address:  filepath.Join(dir, "socket"),
+		Hostname: "my host",
+		AppName:  strings.Repeat("a", 60),
+	})
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.SetClock(log.NewFakeClock(time.Date(2020, time.August, 29, 5, 41, 0, 123456789, time.UTC)))
+	logger := builder.Build().(*log.SinkLogger)
+	defer logger.Close()
+
+	if code, err := logger.Log("INFO", "Hello, syslog"); code != log.Success {
+		t.Fatalf("Log returned %v: %v", code, err)
+	}
+
+	select {
+	case message := <-received:
+		header := "<14>1 2020-08-29T05:41:00.123456Z my_host " + strings.Repeat("a", 48) + " "
+		parts := strings.SplitN(message, " ", 2)
+		if len(parts) != 2 || parts[0] != strconv.Itoa(len(parts[1])) || !strings.HasPrefix(parts[1], header) {
+			t.Fatalf("the message was sent as %q", message)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("nothing was received")
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"bytes"
+	log "github.com/xaanit/simple-logger"
+	"github.com/xaanit/simple-logger/logtest"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeZones(t *testing.T) {
+	eastern := time.FixedZone("EDT", -4*60*60)
+	builder := log.NewGenericLoggerBuilder()
+	builder.AddLevel("INFO", log.Info).
+		SetLocation(eastern).
+		AddColumn(func(context log.Context) string { return context.FormatTime(log.RFC3339) }).
+		AddColumn(log.InLocation(time.UTC, func(context log.Context) string { return context.FormatTime(log.ISO8601Millis) })).
+		AddColumn(func(context log.Context) string { return context.FormatTime(log.UnixMillis) }).
+		AddColumn(func(context log.Context) string { return context.FormatTimestamp(log.UnixSeconds) })
+
+	logger, err := logtest.NewRecordingLogger(builder)
+	if err != nil {
+		t.Fatalf("NewRecordingLogger failed: %v", err)
+	}
+	logger.Clock().Set(time.Date(2020, time.August, 29, 9, 41, 0, 250000000, time.UTC))
+	_, _ = logger.Log("INFO", "Hello, world")
+
+	expected := "2020-08-29T05:41:00-04:00 | 2020-08-29T09:41:00.250Z | 1598694060250 | 1598694060"
+	if line := logger.Lines()[0]; line != expected {
+		t.Fatalf("the line was [%v] not [%v]", line, expected)
+	}
+
+	config, err := log.ParseConfig([]byte(`{"time_zone": "UTC", "columns": ["timestamp"]}`), "json")
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	configured, err := config.Builder()
+	if err != nil {
+		t.Fatalf("Builder failed: %v", err)
+	}
+	recording, err := logtest.NewRecordingLogger(configured)
+	if err != nil {
+		t.Fatalf("NewRecordingLogger failed: %v", err)
+	}
+	recording.Clock().Set(time.Date(2020, time.August, 29, 5, 41, 0, 0, eastern))
+	_, _ = recording.Log("INFO", "Hello, world")
+	if line := recording.Lines()[0]; line != "Saturday August 29, 2020 @ 9:41:00" {
+		t.Fatalf("the line was [%v] not in UTC", line)
+	}
+
+	config.TimeZone = "Nowhere/Nothing"
+	if _, err := config.Builder(); err == nil {
+		t.Fatalf("Builder accepted an unknown time zone")
+	}
+}
+
+func TestTimeZoneOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "simple-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "logging.json")
+	if err := ioutil.WriteFile(path, []byte(`{"columns": ["timestamp"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	builder := log.ConsoleLoggerBuilderWithWriter(&output)
+	log.SetDefaults(builder, nil, nil, nil)
+	builder.SetClock(log.NewFakeClock(time.Date(2020, time.August, 29, 9, 41, 0, 0, time.UTC))).
+		SetLocation(time.FixedZone("EDT", -4*60*60))
+	logger := builder.Build().(log.ReconfigurableLogger)
+
+	watcher, err := log.WatchConfig(path, time.Hour, logger)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	output.Reset()
+	_, _ = logger.Log("INFO", "Hello, world")
+	if !strings.Contains(output.String(), "Saturday August 29, 2020 @ 5:41:00") {
+		t.Fatalf("the line was [%v] not in the time zone set before the reload", output.String())
+	}
+
+	_ = os.Setenv(log.EnvTimeZone, "UTC")
+	config, err := log.ReadConfig(path)
+	_ = os.Unsetenv(log.EnvTimeZone)
+	if err != nil {
+		t.Fatalf("ReadConfig failed: %v", err)
+	}
+	if config.TimeZone != "UTC" {
+		t.Fatalf("%v didn't override the time zone, it was [%v]", log.EnvTimeZone, config.TimeZone)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	"fmt"
+	log "github.com/xaanit/simple-logger"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskBuffer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatalf("could not create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := log.DiskBufferConfig{Dir: dir, SegmentSize: 32, MaxBytes: 80}
+	buffer, err := log.NewDiskBuffer(config)
+	if err != nil {
+		t.Fatalf("NewDiskBuffer failed: %v", err)
+	}
+	for index := 0; index < 5; index++ {
+		if err := buffer.Push(fmt.Sprintf("line %v", index)); err != nil {
+			t.Fatalf("Push failed on line %v: %v", index, err)
+		}
+	}
+	if err := buffer.Push("over the budget"); err == nil {
+		t.Fatalf("Push went over MaxBytes")
+	}
+	if err := buffer.Pop(); err != nil {
+		t.Fatalf("Pop failed: %v", err)
+	}
+	if err := buffer.Pop(); err != nil {
+		t.Fatalf("Pop failed: %v", err)
+	}
+	_ = buffer.Close()
+
+	segments, _ := filepath.Glob(filepath.Join(dir, "*.wal"))
+	if len(segments) != 2 {
+		t.Fatalf("%v segments were kept not 2", segments)
+	}
+	last, _ := os.OpenFile(segments[len(segments)-1], os.O_APPEND|os.O_WRONLY, 0644)
+	_, _ = last.Write([]byte{9, 0, 0, 0, 1, 2, 3, 4, 't', 'o', 'r', 'n'})
+	_ = last.Close()
+
+	buffer, err = log.NewDiskBuffer(config)
+	if err != nil {
+		t.Fatalf("NewDiskBuffer failed to reopen: %v", err)
+	}
+	defer buffer.Close()
+
+	if buffer.Len() != 3 {
+		t.Fatalf("%v lines were replayed not 3", buffer.Len())
+	}
+	if err := buffer.Push("line 5"); err != nil {
+		t.Fatalf("Push failed after reopening: %v", err)
+	}
+	for index := 2; index <= 5; index++ {
+		line, ok := buffer.Peek()
+		if !ok || line != fmt.Sprintf("line %v", index) {
+			t.Fatalf("Peek returned %q not line %v", line, index)
+		}
+		_ = buffer.Pop()
+	}
+	if _, ok := buffer.Peek(); ok || buffer.Len() != 0 {
+		t.Fatalf("the buffer was not empty after popping everything")
+	}
+}
+
+func TestDiskBufferCorruption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	if err != nil {
+		t.Fatalf("could not create a directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := log.DiskBufferConfig{Dir: dir}
+	for _, garbage := range [][]byte{make([]byte, 4096), {0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}} {
+		buffer, err := log.NewDiskBuffer(config)
+		if err != nil {
+			t.Fatalf("NewDiskBuffer failed: %v", err)
+		}
+		if err := buffer.Push("line"); err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+		_ = buffer.Close()
+
+		segments, _ := filepath.Glob(filepath.Join(dir, "*.wal"))
+		last, _ := os.OpenFile(segments[len(segments)-1], os.O_APPEND|os.O_WRONLY, 0644)
+		_, _ = last.Write(garbage)
+		_ = last.Close()
+
+		buffer, err = log.NewDiskBuffer(config)
+		if err != nil {
+			t.Fatalf("NewDiskBuffer failed to reopen after %v bytes of garbage: %v", len(garbage), err)
+		}
+		if line, _ := buffer.Peek(); buffer.Len() != 1 || line != "line" {
+			t.Fatalf("%v lines starting with %q were replayed not 1", buffer.Len(), line)
+		}
+		_ = buffer.Pop()
+		_ = buffer.Close()
+	}
+}
@@ -0,0 +1,66 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package Tests
+
+import (
+	log "github.com/xaanit/simple-logger"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestConfigWatcherReloadsOnSIGHUP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "simple-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "logging.json")
+	if err := ioutil.WriteFile(path, []byte(`{"min_level": "WARNING"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := log.ConsoleLoggerBuilderWithWriter(ioutil.Discard)
+	log.SetDefaults(builder, nil, nil, nil)
+	logger := builder.Build().(log.ReconfigurableLogger)
+
+	// Without an interval the file is only read on SIGHUP.
+	watcher, err := log.WatchConfig(path, 0, logger)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer watcher.Close()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("could not send SIGHUP: %v", err)
+	}
+	for start := time.Now(); logger.GetMinLevel() != "WARNING"; time.Sleep(5 * time.Millisecond) {
+		if time.Since(start) > 2*time.Second {
+			t.Fatalf("the minimum level was [%v] not WARNING after SIGHUP", logger.GetMinLevel())
+		}
+	}
+}
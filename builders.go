@@ -20,8 +20,11 @@
 package simple_logger
 
 import (
+	"errors"
 	"fmt"
-	"github.com/logrusorgru/aurora/v3"
+	"sort"
+	"strings"
+	"time"
 )
 
 // Defines the methods needed to build a Logger instance
@@ -29,61 +32,148 @@ type LoggerBuilder interface {
 	// Adds a level for the Logger to use. The name is called from the Logger.Log function
 	// and the display is used in the actual logging.
 	AddLevel(name string, display func() string) LoggerBuilder
+	// Sets the severity of a level. Levels without a severity are logged regardless of the minimum level.
+	SetSeverity(name string, severity int) LoggerBuilder
+	// Sets the minimum level to log. Levels with a lower severity are skipped, an empty name logs every level.
+	// Levels without a severity are never skipped, and the minimum level itself needs a severity.
+	SetMinLevel(name string) LoggerBuilder
+	// Stops a level from being logged without removing it, regardless of the minimum level.
+	DisableLevel(name string) LoggerBuilder
+	// Allows a level that was disabled with LoggerBuilder.DisableLevel to be logged again.
+	EnableLevel(name string) LoggerBuilder
+	// Removes a level from the Logger. Removing a level that was never added does nothing.
+	RemoveLevel(name string) LoggerBuilder
+	// Returns whether a level with the name passed has been added.
+	HasLevel(name string) bool
+	// Returns the names of every level added, sorted by name.
+	ListLevels() []string
 	// Adds a level of padding to the Logger. This only works with default Padding.
 	AddPadding(padding Padding) LoggerBuilder
+	// Removes a level of padding from the Logger.
+	RemovePadding(padding Padding) LoggerBuilder
 	// Adds a new Column to the Logger. This should always add to the end of the list.
 	AddColumn(column Column) LoggerBuilder
-	// Adds a new Column into the index passed. This should error if the index does not exist,
-	// but should add to the if the length (or more) of the underlying array is passed.
+	// Adds a new Column into the index passed. This should error if the Column is nil,
+	// but should add to the end if the length (or more) of the underlying array is passed.
 	AddColumnByIndex(index uint, column Column) (LoggerBuilder, error)
+	// Adds a new Column to the end of the list under a name, so it can be managed later on.
+	AddNamedColumn(name string, column Column) LoggerBuilder
+	// Same as LoggerBuilder.AddColumnByIndex, but the Column is registered under a name.
+	AddNamedColumnByIndex(index uint, name string, column Column) (LoggerBuilder, error)
+	// Same as LoggerBuilder.AddNamedColumnByIndex, but declares the Padding s the Column uses, like
+	// TimestampPadding for a Column calling Context.FormatTimestamp. LoggerBuilder.BuildE reports Padding s
+	// that no Column declares.
+	AddPaddedColumn(index uint, name string, column Column, paddings ...Padding) (LoggerBuilder, error)
+	// Removes the Column with the name passed. This should error if there is no such Column.
+	RemoveColumn(name string) (LoggerBuilder, error)
+	// Replaces the Column with the name passed, keeping its position, name and Padding s.
+	ReplaceColumn(name string, column Column) (LoggerBuilder, error)
+	// Moves the Column with the name passed to the index passed. An index past the end moves it to the end.
+	MoveColumn(name string, index uint) (LoggerBuilder, error)
+	// Inserts a new named Column directly before the Column with the name passed.
+	InsertBefore(name, newName string, column Column) (LoggerBuilder, error)
+	// Inserts a new named Column directly after the Column with the name passed.
+	InsertAfter(name, newName string, column Column) (LoggerBuilder, error)
+	// Samples messages with the same level and message, see Sampling. A zero interval turns sampling off.
+	SetSampling(first, thereafter int, interval time.Duration) LoggerBuilder
+	// Limits how many messages of a level are logged, see RateLimit. A perSecond of 0 removes the limit.
+	SetRateLimit(level string, perSecond float64, burst int) LoggerBuilder
+	// Adds a Hook that's called for the levels passed, or every level if none are passed.
+	// Hooks are called in the order they were added.
+	AddHook(hook Hook, levels ...string) LoggerBuilder
+	// Adds Redactor s that are applied to the message, extra info and Fields of a Context before any Hook
+	// or Column sees them.
+	AddRedactor(redactors ...Redactor) LoggerBuilder
+	// Adds Extractor s that fill in the Fields of messages logged through ContextLogger.LogCtx.
+	AddExtractor(extractors ...Extractor) LoggerBuilder
+	// Sets the name of the Logger, which is available to Column s through Context.Name.
+	SetName(name string) LoggerBuilder
+	// Sets the Encoder that turns the output of the Column s into a line. Defaults to TextEncoder.
+	SetEncoder(encoder Encoder) LoggerBuilder
+	// Sets the Clock the time of messages is taken from. Defaults to SystemClock.
+	SetClock(clock Clock) LoggerBuilder
+	// Sets the time zone the time of messages is in, e.g. time.UTC. Defaults to time.Local, see InLocation to
+	// override it for a single Column.
+	SetLocation(location *time.Location) LoggerBuilder
+	// Returns a copy of this builder that can be changed without affecting the original.
+	Clone() LoggerBuilder
 	// Builds a new Logger instance.
 	Build() Logger
+	// Validates the configuration and builds a new Logger instance. Every problem found is
+	// reported at once through a *BuildError, in which case no Logger is returned.
+	BuildE() (Logger, error)
+}
+
+// Implemented by the builders of this package, so their configuration can be swapped out as a whole
+// while keeping where they log to.
+type genericLoggerBuilder interface {
+	LoggerBuilder
+	generic() *GenericLoggerBuilder
+	setGeneric(builder *GenericLoggerBuilder)
+}
+
+// Implemented by the Logger s of this package, so their configuration can be checked without changing it.
+type configuredLogger interface {
+	configuration() *GenericLoggerBuilder
+}
+
+// Returned from LoggerBuilder.BuildE when the configuration can't produce a working Logger.
+type BuildError struct {
+	Problems []string
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("invalid logger configuration: %v", strings.Join(e.Problems, "; "))
 }
 
 /* Sets the default for this builder. The defaults are outlined below:
 
  	Levels:
-	 +---------+----------+--------------------------+
-	 | Name    | Variable | Colours                  |
-	 +---------+----------+--------------------------+
-	 | INFO    | Info     | aurora.Cyan              |
-	 +---------+----------+--------------------------+
-	 | DEBUG   | Debug    | aurora.Green             |
-	 +---------+----------+--------------------------+
-	 | ERROR   | Error    | aurora.Red               |
-	 +---------+----------+--------------------------+
-	 | FATAL   | Fatal    | aurora.Bold + aurora.Red |
-	 +---------+----------+--------------------------+
-	 | WARNING | Warning  | aurora.Yellow            |
-	 +---------+----------+--------------------------+
+	 +---------+----------+--------------------------+-----------------+
+	 | Name    | Variable | Colours                  | Severity        |
+	 +---------+----------+--------------------------+-----------------+
+	 | INFO    | Info     | aurora.Cyan              | InfoSeverity    |
+	 +---------+----------+--------------------------+-----------------+
+	 | DEBUG   | Debug    | aurora.Green             | DebugSeverity   |
+	 +---------+----------+--------------------------+-----------------+
+	 | ERROR   | Error    | aurora.Red               | ErrorSeverity   |
+	 +---------+----------+--------------------------+-----------------+
+	 | FATAL   | Fatal    | aurora.Bold + aurora.Red | FatalSeverity   |
+	 +---------+----------+--------------------------+-----------------+
+	 | WARNING | Warning  | aurora.Yellow            | WarningSeverity |
+	 +---------+----------+--------------------------+-----------------+
 
  	Paddings:
  		- TimestampPadding
  		- LevelPadding
 
  	Columns:
- 		+-----------+-------+---------+
- 		| Timestamp | Level | Message |
- 		+-----------+-------+---------+
+ 		+-----------------+-------------+---------------+
+ 		| Timestamp       | Level       | Message       |
+ 		+-----------------+-------------+---------------+
+ 		| TimestampColumn | LevelColumn | MessageColumn |
+ 		+-----------------+-------------+---------------+
 
 
  	The last three arguments are for excluding certain defaults.
  	If you'd like to only have the Info, Warning, and Error Levels you'd pass []string{"FATAL", "DEBUG"}.
 
  	Columns in this way are indexed based starting from 0. If you'd like to remove the timestamp portion you'd pass []int{0}
+ 	The Columns are also registered under the names in the second row, so they can be removed, replaced or moved
+ 	afterwards with LoggerBuilder.RemoveColumn and friends instead.
 */
 func SetDefaults(builder LoggerBuilder, excludeLevels []string, excludePaddings []Padding, excludeColumns []uint) LoggerBuilder {
-	defaultLevels := func(level string, display func() string) {
+	defaultLevels := func(level string, display func() string, severity int) {
 		if findStrings(excludeLevels, level) == -1 {
-			builder.AddLevel(level, display)
+			builder.AddLevel(level, display).SetSeverity(level, severity)
 		}
 	}
 
-	defaultLevels("INFO", Info)
-	defaultLevels("DEBUG", Debug)
-	defaultLevels("ERROR", Error)
-	defaultLevels("FATAL", Fatal)
-	defaultLevels("WARNING", Warning)
+	defaultLevels("INFO", Info, InfoSeverity)
+	defaultLevels("DEBUG", Debug, DebugSeverity)
+	defaultLevels("ERROR", Error, ErrorSeverity)
+	defaultLevels("FATAL", Fatal, FatalSeverity)
+	defaultLevels("WARNING", Warning, WarningSeverity)
 
 	defaultPaddings := func(padding Padding) {
 		if findPadding(excludePaddings, padding) == -1 {
@@ -94,18 +184,15 @@ func SetDefaults(builder LoggerBuilder, excludeLevels []string, excludePaddings
 	defaultPaddings(TimestampPadding)
 	defaultPaddings(LevelPadding)
 
-	defaultColumns := func(column uint, display Column) {
+	defaultColumns := func(column uint, name string, display Column) {
 		if findInts(excludeColumns, column) == -1 {
-			_, _ = builder.AddColumnByIndex(column, display)
+			_, _ = builder.AddPaddedColumn(column, name, display, lookupPaddings(name)...)
 		}
 	}
 
-	defaultColumns(0, func(context Context) string {
-		layout := fmt.Sprintf("%v %v %v, %v @ %v:%v:%v", Weekday, Month, Day, Year, Hour, Minute, Second)
-		return aurora.BrightBlue(context.FormatTimestamp(layout)).String()
-	})
-	defaultColumns(1, func(context Context) string { return context.FormatLevel() })
-	defaultColumns(2, func(context Context) string { return context.Message })
+	defaultColumns(0, TimestampColumn, timestampColumn)
+	defaultColumns(1, LevelColumn, levelColumn)
+	defaultColumns(2, MessageColumn, messageColumn)
 
 	return builder
 }
@@ -114,16 +201,51 @@ func SetDefaults(builder LoggerBuilder, excludeLevels []string, excludePaddings
 // default methods in interfaces.
 func NewGenericLoggerBuilder() *GenericLoggerBuilder {
 	return &GenericLoggerBuilder{
-		Levels:   make(map[string]func() string),
-		Paddings: make(map[Padding]interface{}),
-		Columns:  make([]Column, 0),
+		Levels:         make(map[string]func() string),
+		Severities:     make(map[string]int),
+		DisabledLevels: make(map[string]interface{}),
+		Paddings:       make(map[Padding]interface{}),
+		Columns:        make([]Column, 0),
+		ColumnNames:    make([]string, 0),
+		ColumnPaddings: make([][]Padding, 0),
+		RateLimits:     make(map[string]RateLimit),
+		Hooks:          make([]LevelHook, 0),
+		Redactors:      make([]Redactor, 0),
+		Extractors:     make([]Extractor, 0),
 	}
 }
 
 type GenericLoggerBuilder struct {
-	Levels   map[string]func() string
-	Paddings map[Padding]interface{}
-	Columns  []Column
+	// The name of the Logger, empty by default.
+	Name       string
+	Levels     map[string]func() string
+	Severities map[string]int
+	// The minimum level to log, or an empty string to log every level.
+	MinLevel string
+	// The levels that are never logged.
+	DisabledLevels map[string]interface{}
+	Paddings       map[Padding]interface{}
+	Columns        []Column
+	// The name of each Column, by index. Unnamed Columns have an empty name.
+	ColumnNames []string
+	// The Padding s each Column uses, by index, see LoggerBuilder.AddPaddedColumn.
+	ColumnPaddings [][]Padding
+	// The Encoder to use, or nil for TextEncoder.
+	Encoder Encoder
+	// The Clock to use, or nil for SystemClock.
+	Clock Clock
+	// The time zone to use, or nil for time.Local.
+	Location *time.Location
+	// The Sampling to apply, or nil to log everything.
+	Sampling *Sampling
+	// The RateLimit of each level that has one.
+	RateLimits map[string]RateLimit
+	// The Hook s to call, in order.
+	Hooks []LevelHook
+	// The Redactor s to apply, in order.
+	Redactors []Redactor
+	// The Extractor s to call, in order.
+	Extractors []Extractor
 }
 
 // Implements LoggerBuilder.AddLevel
@@ -132,32 +254,459 @@ func (b *GenericLoggerBuilder) AddLevel(name string, display func() string) Logg
 	return b
 }
 
+// Implements LoggerBuilder.SetSeverity
+func (b *GenericLoggerBuilder) SetSeverity(name string, severity int) LoggerBuilder {
+	b.Severities[name] = severity
+	return b
+}
+
+// Implements LoggerBuilder.SetMinLevel
+func (b *GenericLoggerBuilder) SetMinLevel(name string) LoggerBuilder {
+	b.MinLevel = name
+	return b
+}
+
+// Implements LoggerBuilder.DisableLevel
+func (b *GenericLoggerBuilder) DisableLevel(name string) LoggerBuilder {
+	b.DisabledLevels[name] = nil
+	return b
+}
+
+// Implements LoggerBuilder.EnableLevel
+func (b *GenericLoggerBuilder) EnableLevel(name string) LoggerBuilder {
+	delete(b.DisabledLevels, name)
+	return b
+}
+
+// Implements LoggerBuilder.RemoveLevel
+func (b *GenericLoggerBuilder) RemoveLevel(name string) LoggerBuilder {
+	delete(b.Levels, name)
+	delete(b.Severities, name)
+	delete(b.DisabledLevels, name)
+	return b
+}
+
+// Implements LoggerBuilder.HasLevel
+func (b *GenericLoggerBuilder) HasLevel(name string) bool {
+	_, ok := b.Levels[name]
+	return ok
+}
+
+// Implements LoggerBuilder.ListLevels
+func (b *GenericLoggerBuilder) ListLevels() []string {
+	names := make([]string, 0, len(b.Levels))
+	for name := range b.Levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Implements LoggerBuilder.AddPadding
 func (b *GenericLoggerBuilder) AddPadding(padding Padding) LoggerBuilder {
 	b.Paddings[padding] = nil
 	return b
 }
 
+// Implements LoggerBuilder.RemovePadding
+func (b *GenericLoggerBuilder) RemovePadding(padding Padding) LoggerBuilder {
+	delete(b.Paddings, padding)
+	return b
+}
+
 // Implements LoggerBuilder.AddColumn
 func (b *GenericLoggerBuilder) AddColumn(column Column) LoggerBuilder {
-	b.Columns = append(b.Columns, column)
-	return b
+	return b.AddNamedColumn("", column)
 }
 
 // Implements LoggerBuilder.AddColumnByIndex
 func (b *GenericLoggerBuilder) AddColumnByIndex(index uint, column Column) (LoggerBuilder, error) {
-	if index >= uint(len(b.Columns)) {
-		b.AddColumn(column)
-		return b, nil
+	return b.AddNamedColumnByIndex(index, "", column)
+}
+
+// Implements LoggerBuilder.AddNamedColumn
+func (b *GenericLoggerBuilder) AddNamedColumn(name string, column Column) LoggerBuilder {
+	b.insertColumn(uint(len(b.Columns)), name, column, nil)
+	return b
+}
+
+// Implements LoggerBuilder.AddNamedColumnByIndex
+func (b *GenericLoggerBuilder) AddNamedColumnByIndex(index uint, name string, column Column) (LoggerBuilder, error) {
+	return b.AddPaddedColumn(index, name, column)
+}
+
+// Implements LoggerBuilder.AddPaddedColumn
+func (b *GenericLoggerBuilder) AddPaddedColumn(index uint, name string, column Column, paddings ...Padding) (LoggerBuilder, error) {
+	if column == nil {
+		return b, errors.New("column must not be nil")
+	}
+
+	b.insertColumn(index, name, column, paddings)
+	return b, nil
+}
+
+// Inserts a Column at the index, or at the end if the index is past it.
+func (b *GenericLoggerBuilder) insertColumn(index uint, name string, column Column, paddings []Padding) {
+	b.syncColumnNames()
+	if index > uint(len(b.Columns)) {
+		index = uint(len(b.Columns))
 	}
 
 	// https://stackoverflow.com/questions/46128016/insert-a-value-in-a-slice-at-a-given-index
 	b.Columns = append(b.Columns, nil)
 	copy(b.Columns[index+1:], b.Columns[index:])
+	b.Columns[index] = column
+
+	b.ColumnNames = append(b.ColumnNames, "")
+	copy(b.ColumnNames[index+1:], b.ColumnNames[index:])
+	b.ColumnNames[index] = name
+
+	b.ColumnPaddings = append(b.ColumnPaddings, nil)
+	copy(b.ColumnPaddings[index+1:], b.ColumnPaddings[index:])
+	b.ColumnPaddings[index] = append([]Padding(nil), paddings...)
+}
+
+// Implements LoggerBuilder.RemoveColumn
+func (b *GenericLoggerBuilder) RemoveColumn(name string) (LoggerBuilder, error) {
+	index, err := b.findColumn(name)
+	if err != nil {
+		return b, err
+	}
+
+	b.Columns = append(b.Columns[:index], b.Columns[index+1:]...)
+	b.ColumnNames = append(b.ColumnNames[:index], b.ColumnNames[index+1:]...)
+	b.ColumnPaddings = append(b.ColumnPaddings[:index], b.ColumnPaddings[index+1:]...)
+	return b, nil
+}
+
+// Implements LoggerBuilder.ReplaceColumn
+func (b *GenericLoggerBuilder) ReplaceColumn(name string, column Column) (LoggerBuilder, error) {
+	if column == nil {
+		return b, errors.New("column must not be nil")
+	}
+
+	index, err := b.findColumn(name)
+	if err != nil {
+		return b, err
+	}
+
 	b.Columns[index] = column
 	return b, nil
 }
 
+// Implements LoggerBuilder.MoveColumn
+func (b *GenericLoggerBuilder) MoveColumn(name string, index uint) (LoggerBuilder, error) {
+	current, err := b.findColumn(name)
+	if err != nil {
+		return b, err
+	}
+
+	column, paddings := b.Columns[current], b.ColumnPaddings[current]
+	b.Columns = append(b.Columns[:current], b.Columns[current+1:]...)
+	b.ColumnNames = append(b.ColumnNames[:current], b.ColumnNames[current+1:]...)
+	b.ColumnPaddings = append(b.ColumnPaddings[:current], b.ColumnPaddings[current+1:]...)
+	return b.AddPaddedColumn(index, name, column, paddings...)
+}
+
+// Implements LoggerBuilder.InsertBefore
+func (b *GenericLoggerBuilder) InsertBefore(name, newName string, column Column) (LoggerBuilder, error) {
+	index, err := b.findColumn(name)
+	if err != nil {
+		return b, err
+	}
+	return b.AddNamedColumnByIndex(uint(index), newName, column)
+}
+
+// Implements LoggerBuilder.InsertAfter
+func (b *GenericLoggerBuilder) InsertAfter(name, newName string, column Column) (LoggerBuilder, error) {
+	index, err := b.findColumn(name)
+	if err != nil {
+		return b, err
+	}
+	return b.AddNamedColumnByIndex(uint(index+1), newName, column)
+}
+
+// Returns the index of the first Column registered under the name passed.
+func (b *GenericLoggerBuilder) findColumn(name string) (int, error) {
+	b.syncColumnNames()
+	if name == "" {
+		return -1, errors.New("unnamed columns can't be looked up")
+	}
+
+	if index := findStrings(b.ColumnNames, name); index != -1 {
+		return index, nil
+	}
+	return -1, fmt.Errorf("there is no column named %v", name)
+}
+
+// Keeps ColumnNames and ColumnPaddings the same length as Columns, in case Columns was changed directly.
+func (b *GenericLoggerBuilder) syncColumnNames() {
+	for len(b.ColumnNames) < len(b.Columns) {
+		b.ColumnNames = append(b.ColumnNames, "")
+	}
+	b.ColumnNames = b.ColumnNames[:len(b.Columns)]
+
+	for len(b.ColumnPaddings) < len(b.Columns) {
+		b.ColumnPaddings = append(b.ColumnPaddings, nil)
+	}
+	b.ColumnPaddings = b.ColumnPaddings[:len(b.Columns)]
+}
+
+// Implements LoggerBuilder.SetSampling
+func (b *GenericLoggerBuilder) SetSampling(first, thereafter int, interval time.Duration) LoggerBuilder {
+	if interval <= 0 {
+		b.Sampling = nil
+		return b
+	}
+
+	b.Sampling = &Sampling{
+		First:      first,
+		Thereafter: thereafter,
+		Interval:   interval,
+	}
+	return b
+}
+
+// Implements LoggerBuilder.SetRateLimit
+func (b *GenericLoggerBuilder) SetRateLimit(level string, perSecond float64, burst int) LoggerBuilder {
+	if perSecond <= 0 {
+		delete(b.RateLimits, level)
+		return b
+	}
+
+	b.RateLimits[level] = RateLimit{
+		PerSecond: perSecond,
+		Burst:     burst,
+	}
+	return b
+}
+
+// Implements LoggerBuilder.AddHook
+func (b *GenericLoggerBuilder) AddHook(hook Hook, levels ...string) LoggerBuilder {
+	b.Hooks = append(b.Hooks, LevelHook{
+		Hook:   hook,
+		Levels: append(make([]string, 0, len(levels)), levels...),
+	})
+	return b
+}
+
+// Implements LoggerBuilder.AddRedactor
+func (b *GenericLoggerBuilder) AddRedactor(redactors ...Redactor) LoggerBuilder {
+	b.Redactors = append(b.Redactors, redactors...)
+	return b
+}
+
+// Implements LoggerBuilder.AddExtractor
+func (b *GenericLoggerBuilder) AddExtractor(extractors ...Extractor) LoggerBuilder {
+	b.Extractors = append(b.Extractors, extractors...)
+	return b
+}
+
+// Implements LoggerBuilder.SetName
+func (b *GenericLoggerBuilder) SetName(name string) LoggerBuilder {
+	b.Name = name
+	return b
+}
+
+// Implements LoggerBuilder.SetEncoder
+func (b *GenericLoggerBuilder) SetEncoder(encoder Encoder) LoggerBuilder {
+	b.Encoder = encoder
+	return b
+}
+
+// Implements LoggerBuilder.SetClock
+func (b *GenericLoggerBuilder) SetClock(clock Clock) LoggerBuilder {
+	b.Clock = clock
+	return b
+}
+
+// Implements LoggerBuilder.SetLocation
+func (b *GenericLoggerBuilder) SetLocation(location *time.Location) LoggerBuilder {
+	b.Location = location
+	return b
+}
+
+// Implements LoggerBuilder.Clone
+func (b *GenericLoggerBuilder) Clone() LoggerBuilder {
+	return b.clone()
+}
+
+func (b *GenericLoggerBuilder) clone() *GenericLoggerBuilder {
+	b.syncColumnNames()
+	clone := NewGenericLoggerBuilder()
+
+	for name, display := range b.Levels {
+		clone.Levels[name] = display
+	}
+	for name, severity := range b.Severities {
+		clone.Severities[name] = severity
+	}
+	for name := range b.DisabledLevels {
+		clone.DisabledLevels[name] = nil
+	}
+	for padding := range b.Paddings {
+		clone.Paddings[padding] = nil
+	}
+	clone.Name = b.Name
+	clone.MinLevel = b.MinLevel
+	clone.Encoder = b.Encoder
+	clone.Clock = b.Clock
+	clone.Location = b.Location
+	for level, limit := range b.RateLimits {
+		clone.RateLimits[level] = limit
+	}
+	if b.Sampling != nil {
+		sampling := *b.Sampling
+		clone.Sampling = &sampling
+	}
+	clone.Hooks = append(clone.Hooks, b.Hooks...)
+	clone.Redactors = append(clone.Redactors, b.Redactors...)
+	clone.Extractors = append(clone.Extractors, b.Extractors...)
+	clone.Columns = append(clone.Columns, b.Columns...)
+	clone.ColumnNames = append(clone.ColumnNames, b.ColumnNames...)
+	for _, paddings := range b.ColumnPaddings {
+		clone.ColumnPaddings = append(clone.ColumnPaddings, append([]Padding(nil), paddings...))
+	}
+
+	return clone
+}
+
+func (b *GenericLoggerBuilder) generic() *GenericLoggerBuilder {
+	return b
+}
+
+func (b *GenericLoggerBuilder) setGeneric(builder *GenericLoggerBuilder) {
+	*b = *builder
+}
+
 func (b *GenericLoggerBuilder) Build() Logger {
 	return nil
 }
+
+// Implements LoggerBuilder.BuildE. A GenericLoggerBuilder has nothing to build, so this only validates.
+func (b *GenericLoggerBuilder) BuildE() (Logger, error) {
+	return nil, b.Validate()
+}
+
+/*
+	Checks that this builder can produce a working Logger. This reports:
+
+		- No Levels or no Columns being set
+		- Levels with a nil display function and nil Columns
+		- A minimum level that isn't a level or has no severity
+		- Negative sampling counts and rate limits of levels that don't exist or can't log anything
+		- Nil Hook s and Hook s added for levels that don't exist
+		- Nil Redactor s and Extractor s
+		- Column names that are used more than once
+		- Unknown Padding values, and Padding s no Column declares it uses, see LoggerBuilder.AddPaddedColumn
+
+	Column s aren't run, only the configuration itself is checked.
+
+	Returns nil when the configuration is valid, otherwise a *BuildError with every problem found.
+*/
+func (b *GenericLoggerBuilder) Validate() error {
+	b.syncColumnNames()
+	problems := make([]string, 0)
+
+	if len(b.Levels) == 0 {
+		problems = append(problems, "at least one level must be added")
+	}
+
+	for _, name := range b.ListLevels() {
+		if b.Levels[name] == nil {
+			problems = append(problems, fmt.Sprintf("level %v has a nil display function", name))
+		}
+	}
+
+	if b.MinLevel != "" && !b.HasLevel(b.MinLevel) {
+		problems = append(problems, fmt.Sprintf("minimum level %v is not a level", b.MinLevel))
+	} else if _, ok := b.Severities[b.MinLevel]; b.MinLevel != "" && !ok {
+		problems = append(problems, fmt.Sprintf("minimum level %v has no severity", b.MinLevel))
+	}
+
+	if b.Sampling != nil && (b.Sampling.First < 0 || b.Sampling.Thereafter < 0) {
+		problems = append(problems, "sampling can't use negative counts")
+	}
+
+	for level, limit := range b.RateLimits {
+		if !b.HasLevel(level) {
+			problems = append(problems, fmt.Sprintf("rate limit is set for %v which is not a level", level))
+		}
+		if limit.Burst < 1 {
+			problems = append(problems, fmt.Sprintf("rate limit of %v needs a burst of at least 1", level))
+		}
+	}
+
+	for index, hook := range b.Hooks {
+		if hook.Hook == nil {
+			problems = append(problems, fmt.Sprintf("hook %v is nil", index))
+		}
+		for _, level := range hook.Levels {
+			if !b.HasLevel(level) {
+				problems = append(problems, fmt.Sprintf("hook %v is added for %v which is not a level", index, level))
+			}
+		}
+	}
+
+	for index, redactor := range b.Redactors {
+		if redactor == nil {
+			problems = append(problems, fmt.Sprintf("redactor %v is nil", index))
+		}
+	}
+
+	for index, extractor := range b.Extractors {
+		if extractor == nil {
+			problems = append(problems, fmt.Sprintf("extractor %v is nil", index))
+		}
+	}
+
+	if len(b.Columns) == 0 {
+		problems = append(problems, "at least one column must be added")
+	}
+
+	for index, column := range b.Columns {
+		if column == nil {
+			problems = append(problems, fmt.Sprintf("column %v is nil", index))
+		}
+	}
+
+	b.syncColumnNames()
+	for index, name := range b.ColumnNames {
+		if name != "" && findStrings(b.ColumnNames[:index], name) != -1 {
+			problems = append(problems, fmt.Sprintf("column name %v is used more than once", name))
+		}
+	}
+
+	used := make(map[Padding]interface{})
+	for _, paddings := range b.ColumnPaddings {
+		for _, padding := range paddings {
+			used[padding] = nil
+		}
+	}
+	for padding := range b.Paddings {
+		if padding != TimestampPadding && padding != DatePadding && padding != LevelPadding {
+			problems = append(problems, fmt.Sprintf("unknown padding %v", int(padding)))
+		}
+	}
+	for _, padding := range []Padding{TimestampPadding, DatePadding, LevelPadding} {
+		_, added := b.Paddings[padding]
+		if _, ok := used[padding]; added && !ok {
+			problems = append(problems, fmt.Sprintf("%v is added but no column uses it", paddingName(padding)))
+		}
+	}
+
+	if len(problems) != 0 {
+		return &BuildError{Problems: problems}
+	}
+	return nil
+}
+
+// Returns the name a Padding goes by in a Config.
+func paddingName(padding Padding) string {
+	for name, named := range paddingNames {
+		if named == padding {
+			return name
+		}
+	}
+	return fmt.Sprint(int(padding))
+}
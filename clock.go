@@ -0,0 +1,93 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"sync"
+	"time"
+)
+
+// Where a Logger gets the time of its messages from, see LoggerBuilder.SetClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// The Clock Loggers use unless another one is set, it returns time.Now.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Returns the time of clock in location, with nil meaning SystemClock and time.Local.
+func currentTime(clock Clock, location *time.Location) time.Time {
+	if clock == nil {
+		clock = SystemClock
+	}
+	if location == nil {
+		location = time.Local
+	}
+	return clock.Now().In(location)
+}
+
+// A Clock that only moves when it's told to, for deterministic timestamps in tests.
+type FakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+	step  time.Duration
+}
+
+// Creates a FakeClock at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Implements Clock.Now, then moves the FakeClock forward by its step.
+func (f *FakeClock) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	now := f.now
+	f.now = f.now.Add(f.step)
+	return now
+}
+
+// Makes the FakeClock move forward by step every time it's read, 0 by default.
+func (f *FakeClock) SetStep(step time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.step = step
+}
+
+// Moves the FakeClock forward by duration.
+func (f *FakeClock) Advance(duration time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = f.now.Add(duration)
+}
+
+// Moves the FakeClock to now.
+func (f *FakeClock) Set(now time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = now
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+/*
+	Wraps any Logger so that the same level and message logged repeatedly in a row is written as a single
+	line with a repeat counter:
+
+		Saturday August 29, 2020 @ 5:41:00 | ERROR   | connection refused (x42)
+
+	A message is held back until a different message is logged, the timeout passes without a repeat,
+	or Flush is called. Messages that are only logged once are written as they are.
+*/
+type CollapsingLogger struct {
+	logger  Logger
+	timeout time.Duration
+	mutex   sync.Mutex
+	pending *collapsedEntry
+	timer   *time.Timer
+}
+
+type collapsedEntry struct {
+	level   string
+	message string
+	info    interface{}
+	count   int
+}
+
+// Makes a new CollapsingLogger that writes to logger, holding a message back for at most timeout after its last repeat.
+func NewCollapsingLogger(logger Logger, timeout time.Duration) *CollapsingLogger {
+	return &CollapsingLogger{
+		logger:  logger,
+		timeout: timeout,
+	}
+}
+
+// Implements Logger.GetLevels
+func (c *CollapsingLogger) GetLevels() map[string]func() string {
+	return c.logger.GetLevels()
+}
+
+// Implements Logger.GetPaddings
+func (c *CollapsingLogger) GetPaddings() []Padding {
+	return c.logger.GetPaddings()
+}
+
+// Implements Logger.GetColumns
+func (c *CollapsingLogger) GetColumns() []Column {
+	return c.logger.GetColumns()
+}
+
+// Implements Logger.Log
+func (c *CollapsingLogger) Log(level, message string) (int, error) {
+	return c.LogWithExtraInfo(level, message, nil)
+}
+
+/*
+	Implements Logger.LogWithExtraInfo. The info of the first message of a run of repeats is the one that's written.
+
+	Returns
+		- Success when the message was held back, or the status of the wrapped Logger if an earlier message was
+		  written because of this one
+		- InvalidLevel when the level provided isn't in the wrapped Logger
+*/
+func (c *CollapsingLogger) LogWithExtraInfo(level, message string, info interface{}) (int, error) {
+	if _, ok := c.logger.GetLevels()[level]; !ok {
+		return InvalidLevel, errors.New(fmt.Sprintf("%v is not a valid level for this Logger", level))
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.pending != nil && c.pending.level == level && c.pending.message == message {
+		c.pending.count++
+		c.timer.Reset(c.timeout)
+		return Success, nil
+	}
+
+	status, err := c.flush()
+	entry := &collapsedEntry{
+		level:   level,
+		message: message,
+		info:    info,
+		count:   1,
+	}
+	c.pending = entry
+	c.timer = time.AfterFunc(c.timeout, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		if c.pending == entry {
+			_, _ = c.flush()
+		}
+	})
+	return status, err
+}
+
+// Writes the message that's being held back, if there is one, and returns the status of the wrapped Logger.
+func (c *CollapsingLogger) Flush() (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.flush()
+}
+
+func (c *CollapsingLogger) flush() (int, error) {
+	if c.pending == nil {
+		return Success, nil
+	}
+
+	pending := c.pending
+	c.pending = nil
+	c.timer.Stop()
+
+	message := pending.message
+	if pending.count > 1 {
+		message = fmt.Sprintf("%v (x%v)", message, pending.count)
+	}
+	return c.logger.LogWithExtraInfo(pending.level, message, pending.info)
+}
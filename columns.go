@@ -0,0 +1,122 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+var (
+	columnRegistry = map[string]Column{
+		TimestampColumn: timestampColumn,
+		LevelColumn:     levelColumn,
+		MessageColumn:   messageColumn,
+		NameColumn:      NameColumnFunc,
+		TraceColumn:     TraceIDColumnFunc,
+		SpanColumn:      SpanIDColumnFunc,
+	}
+	// The Padding s the registered Column s use, see LoggerBuilder.AddPaddedColumn.
+	columnRegistryPaddings = map[string][]Padding{
+		TimestampColumn: {TimestampPadding},
+		LevelColumn:     {LevelPadding},
+	}
+	columnRegistryMutex sync.RWMutex
+)
+
+// Registers a Column under a name so it can be used by name, for example from a Config file, along with the
+// Padding s it uses. Registering a name twice replaces the earlier Column.
+func RegisterColumn(name string, column Column, paddings ...Padding) {
+	columnRegistryMutex.Lock()
+	defer columnRegistryMutex.Unlock()
+	columnRegistry[name] = column
+	columnRegistryPaddings[name] = append([]Padding(nil), paddings...)
+}
+
+// Returns the Column registered under the name passed. The Column s from SetDefaults are always
+// registered under TimestampColumn, LevelColumn and MessageColumn, NameColumnFunc under NameColumn,
+// and TraceIDColumnFunc and SpanIDColumnFunc under TraceColumn and SpanColumn.
+func LookupColumn(name string) (Column, bool) {
+	columnRegistryMutex.RLock()
+	defer columnRegistryMutex.RUnlock()
+	column, ok := columnRegistry[name]
+	return column, ok
+}
+
+// Returns the Padding s of the Column registered under the name passed.
+func lookupPaddings(name string) []Padding {
+	columnRegistryMutex.RLock()
+	defer columnRegistryMutex.RUnlock()
+	return columnRegistryPaddings[name]
+}
+
+var templatePlaceholder = regexp.MustCompile("{([^{}]+)}")
+
+// Returns the Padding s of every Column a template made by TemplateColumn uses.
+func templatePaddings(template string) []Padding {
+	paddings := make([]Padding, 0)
+	for _, match := range templatePlaceholder.FindAllStringSubmatch(template, -1) {
+		paddings = append(paddings, lookupPaddings(match[1])...)
+	}
+	return paddings
+}
+
+/*
+	Makes a Column out of a template string, where every {name} is replaced by the output of the
+	Column registered under that name. For example:
+
+		TemplateColumn("[{level}] {message}")
+
+	Returns an error if a placeholder doesn't name a registered Column.
+*/
+func TemplateColumn(template string) (Column, error) {
+	matches := templatePlaceholder.FindAllStringSubmatch(template, -1)
+	columns := make(map[string]Column, len(matches))
+	for _, match := range matches {
+		column, ok := LookupColumn(match[1])
+		if !ok {
+			return nil, fmt.Errorf("there is no column registered as %v", match[1])
+		}
+		columns[match[1]] = column
+	}
+
+	return func(context Context) string {
+		return templatePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+			return columns[placeholder[1:len(placeholder)-1]](context)
+		})
+	}, nil
+}
+
+/*
+	Wraps a Column so it sees the time of messages in location instead of the time zone of the Logger,
+	for example to show UTC next to the local time:
+
+		builder.AddColumn(InLocation(time.UTC, func(context Context) string {
+			return context.FormatTime(ISO8601Millis)
+		}))
+*/
+func InLocation(location *time.Location, column Column) Column {
+	return func(context Context) string {
+		context.Time = context.Time.In(location)
+		return column(context)
+	}
+}
@@ -0,0 +1,328 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/logrusorgru/aurora/v3"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// Overrides Config.MinLevel
+	EnvLevel = "SIMPLE_LOGGER_LEVEL"
+	// Overrides Config.Encoder
+	EnvEncoder = "SIMPLE_LOGGER_ENCODER"
+	// Overrides Config.Sink
+	EnvSink = "SIMPLE_LOGGER_SINK"
+	// Overrides Config.TimeZone
+	EnvTimeZone = "SIMPLE_LOGGER_TIME_ZONE"
+)
+
+/*
+	Describes a Logger without code, usually loaded from a file with LoadConfig. In YAML this looks like:
+
+		levels:
+		  - name: INFO
+		    color: cyan
+		    severity: 20
+		  - name: ERROR
+		    color: bold red
+		    severity: 40
+		paddings: [TimestampPadding, LevelPadding]
+		columns: [timestamp, "[{level}]", message]
+		sink: stderr
+		encoder: text
+		min_level: INFO
+
+	Sections that are left out fall back to what SetDefaults adds, an empty list of paddings means no padding.
+*/
+type Config struct {
+	// The levels to add. When empty the levels from SetDefaults are used.
+	Levels []LevelConfig `json:"levels" yaml:"levels" toml:"levels"`
+	// Padding by name, e.g. TimestampPadding, DatePadding or LevelPadding.
+	Paddings []string `json:"paddings" yaml:"paddings" toml:"paddings"`
+	// Either the name of a registered Column (see RegisterColumn) or a template for TemplateColumn.
+	Columns []string `json:"columns" yaml:"columns" toml:"columns"`
+	// Where to log to, either stdout (the default) or stderr.
+	Sink string `json:"sink" yaml:"sink" toml:"sink"`
+	// How to encode lines, either text (the default) or json.
+	Encoder string `json:"encoder" yaml:"encoder" toml:"encoder"`
+	// The minimum level to log, empty to log every level.
+	MinLevel string `json:"min_level" yaml:"min_level" toml:"min_level"`
+	// The time zone timestamps are in, UTC, Local or an IANA name like Europe/Amsterdam. Empty for the local zone.
+	TimeZone string `json:"time_zone" yaml:"time_zone" toml:"time_zone"`
+}
+
+// Describes a single level of a Config.
+type LevelConfig struct {
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// Space separated aurora colours and formats, e.g. "cyan", "bold red" or "bright blue". Empty for no colour.
+	Color string `json:"color" yaml:"color" toml:"color"`
+	// The severity of the level. When left out the levels from SetDefaults keep their default severity,
+	// and other levels have none so they're logged regardless of the minimum level.
+	Severity *int `json:"severity" yaml:"severity" toml:"severity"`
+}
+
+/*
+	Reads a Config from the file at path, applies the environment overrides and makes a LoggerBuilder out of it.
+	The format is picked from the file extension: .json, .yaml, .yml or .toml.
+*/
+func LoadConfig(path string) (LoggerBuilder, error) {
+	config, err := ReadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return config.Builder()
+}
+
+// Reads a Config from the file at path and applies the environment overrides, see LoadConfig.
+func ReadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ParseConfig(data, strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	config.ApplyEnvironment()
+	return config, nil
+}
+
+// Parses a Config in the format passed, which is one of json, yaml, yml or toml.
+func ParseConfig(data []byte, format string) (*Config, error) {
+	config := &Config{}
+	var err error
+
+	switch strings.ToLower(format) {
+	case "json":
+		err = json.Unmarshal(data, config)
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, config)
+	case "toml":
+		err = toml.Unmarshal(data, config)
+	default:
+		return nil, fmt.Errorf("unknown config format %v", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Overrides the Config with the EnvLevel, EnvEncoder, EnvSink and EnvTimeZone environment variables when they're set.
+func (c *Config) ApplyEnvironment() {
+	if level, ok := os.LookupEnv(EnvLevel); ok {
+		c.MinLevel = level
+	}
+	if encoder, ok := os.LookupEnv(EnvEncoder); ok {
+		c.Encoder = encoder
+	}
+	if sink, ok := os.LookupEnv(EnvSink); ok {
+		c.Sink = sink
+	}
+	if timeZone, ok := os.LookupEnv(EnvTimeZone); ok {
+		c.TimeZone = timeZone
+	}
+}
+
+// Makes a LoggerBuilder for the sink of this Config, with everything else in the Config added to it.
+func (c *Config) Builder() (LoggerBuilder, error) {
+	var builder LoggerBuilder
+	switch strings.ToLower(c.Sink) {
+	case "", "stdout":
+		builder = ConsoleLoggerBuilderWithWriter(os.Stdout)
+	case "stderr":
+		builder = ConsoleLoggerBuilderWithWriter(os.Stderr)
+	default:
+		return nil, fmt.Errorf("unknown sink %v", c.Sink)
+	}
+
+	if err := c.configure(builder); err != nil {
+		return nil, err
+	}
+	return builder, nil
+}
+
+// Adds everything but the sink to an empty builder.
+func (c *Config) configure(builder LoggerBuilder) error {
+	exclude := make([]string, 0)
+	if len(c.Levels) != 0 {
+		exclude = append(exclude, "INFO", "DEBUG", "ERROR", "FATAL", "WARNING")
+	}
+
+	// Without paddings in the file, the columns in it get the paddings they use.
+	excludePaddings := make([]Padding, 0)
+	if c.Paddings != nil || len(c.Columns) != 0 {
+		excludePaddings = append(excludePaddings, TimestampPadding, LevelPadding)
+	}
+
+	excludeColumns := make([]uint, 0)
+	if len(c.Columns) != 0 {
+		excludeColumns = append(excludeColumns, 0, 1, 2)
+	}
+
+	SetDefaults(builder, exclude, excludePaddings, excludeColumns)
+
+	for _, level := range c.Levels {
+		display, err := levelDisplay(level.Name, level.Color)
+		if err != nil {
+			return err
+		}
+
+		builder.AddLevel(level.Name, display)
+		if level.Severity != nil {
+			builder.SetSeverity(level.Name, *level.Severity)
+		} else if severity, ok := defaultSeverities[level.Name]; ok {
+			builder.SetSeverity(level.Name, severity)
+		}
+	}
+
+	for _, name := range c.Paddings {
+		padding, ok := paddingNames[name]
+		if !ok {
+			return fmt.Errorf("unknown padding %v", name)
+		}
+		builder.AddPadding(padding)
+	}
+
+	for _, name := range c.Columns {
+		column, ok := LookupColumn(name)
+		columnName, paddings := name, lookupPaddings(name)
+		if !ok {
+			var err error
+			if column, err = TemplateColumn(name); err != nil {
+				return err
+			}
+			columnName, paddings = "", templatePaddings(name)
+		}
+
+		// An index past the end adds the Column to the end.
+		_, _ = builder.AddPaddedColumn(math.MaxUint32, columnName, column, paddings...)
+		if c.Paddings == nil {
+			for _, padding := range paddings {
+				builder.AddPadding(padding)
+			}
+		}
+	}
+
+	switch strings.ToLower(c.Encoder) {
+	case "", "text":
+		builder.SetEncoder(TextEncoder)
+	case "json":
+		builder.SetEncoder(JSONEncoder)
+	default:
+		return fmt.Errorf("unknown encoder %v", c.Encoder)
+	}
+
+	if c.TimeZone != "" {
+		location, err := time.LoadLocation(c.TimeZone)
+		if err != nil {
+			return fmt.Errorf("unknown time zone %v: %v", c.TimeZone, err)
+		}
+		builder.SetLocation(location)
+	}
+
+	builder.SetMinLevel(c.MinLevel)
+	return nil
+}
+
+// Copies the parts of fresh the Config describes into builder, leaving the rest of builder as it is.
+// The time zone is only copied when the Config sets one.
+func (c *Config) merge(builder, fresh *GenericLoggerBuilder) {
+	builder.Levels = fresh.Levels
+	builder.Severities = fresh.Severities
+	builder.Paddings = fresh.Paddings
+	builder.Columns = fresh.Columns
+	builder.ColumnNames = fresh.ColumnNames
+	builder.ColumnPaddings = fresh.ColumnPaddings
+	builder.Encoder = fresh.Encoder
+	builder.MinLevel = fresh.MinLevel
+	if c.TimeZone != "" {
+		builder.Location = fresh.Location
+	}
+}
+
+var (
+	defaultSeverities = map[string]int{
+		"DEBUG":   DebugSeverity,
+		"INFO":    InfoSeverity,
+		"WARNING": WarningSeverity,
+		"ERROR":   ErrorSeverity,
+		"FATAL":   FatalSeverity,
+	}
+
+	paddingNames = map[string]Padding{
+		"TimestampPadding": TimestampPadding,
+		"DatePadding":      DatePadding,
+		"LevelPadding":     LevelPadding,
+	}
+
+	colorNames = map[string]aurora.Color{
+		"black":     aurora.BlackFg,
+		"red":       aurora.RedFg,
+		"green":     aurora.GreenFg,
+		"yellow":    aurora.YellowFg,
+		"blue":      aurora.BlueFg,
+		"magenta":   aurora.MagentaFg,
+		"cyan":      aurora.CyanFg,
+		"white":     aurora.WhiteFg,
+		"bright":    aurora.BrightFg,
+		"bold":      aurora.BoldFm,
+		"faint":     aurora.FaintFm,
+		"italic":    aurora.ItalicFm,
+		"underline": aurora.UnderlineFm,
+		"blink":     aurora.BlinkFm,
+		"reverse":   aurora.ReverseFm,
+	}
+)
+
+// Makes the display function of a level out of its name and a colour like "bold red".
+func levelDisplay(name, color string) (func() string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("levels must have a name")
+	}
+
+	colorize := aurora.Color(0)
+	for _, word := range strings.Fields(strings.ToLower(color)) {
+		value, ok := colorNames[word]
+		if !ok {
+			return nil, fmt.Errorf("unknown colour %v for level %v", word, name)
+		}
+		colorize |= value
+	}
+
+	if colorize == 0 {
+		return func() string { return name }, nil
+	}
+	return func() string { return aurora.Colorize(name, colorize).String() }, nil
+}
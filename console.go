@@ -20,125 +20,48 @@
 package simple_logger
 
 import (
-	"errors"
 	"fmt"
-	"time"
+	"io"
+	"os"
 )
 
-// A Logger implementation that logs to console using fmt.Println
+// A Logger implementation that logs to console using fmt.Fprintln, by default to os.Stdout
 type ConsoleLogger struct {
-	levels   map[string]func() string
-	paddings []Padding
-	columns  []Column
-}
-
-func (c ConsoleLogger) createContext(level, message string) Context {
-	return Context{
-		Message: message,
-		Time:    time.Now(),
-		Level:   level,
-		Logger:  c,
-	}
-}
-
-// Implements Logger.GetLevels
-func (c ConsoleLogger) GetLevels() map[string]func() string {
-	return c.levels
-}
-
-// Implements Logger.GetPaddings
-func (c ConsoleLogger) GetPaddings() []Padding {
-	return c.paddings
-}
-
-// Implements Logger.GetColumns
-func (c ConsoleLogger) GetColumns() []Column {
-	return c.columns
-}
-
-/*
-	Implements Logger.Log.
-
-	Returns
-		- Success when there was no problems
-		- InvalidLevel when the level provided isn't in this Logger
-		- NoColumnsSet when there are no Columns set for this Logger
-*/
-func (c ConsoleLogger) Log(level, message string) (int, error) {
-	if _, ok := c.GetLevels()[level]; !ok {
-		return InvalidLevel, errors.New(fmt.Sprintf("%v is not a valid level for this Logger", level))
-	}
-
-	if len(c.GetColumns()) == 0 {
-		return NoColumnsSet, errors.New("you must set at least one column")
-	}
-
-	context := c.createContext(level, message)
-	format := ""
-	for index, column := range c.columns {
-		pad := ""
-		if index != 0 {
-			pad = " "
-		}
-
-		end := ""
-		if index != len(c.columns)-1 {
-			end = " |"
-		}
-
-		format += fmt.Sprintf("%v%v%v", pad, column(context), end)
-	}
-
-	fmt.Println(format)
-
-	return Success, nil
-}
-
-func (c ConsoleLogger) LogWithExtraInfo(level, message string, info interface{}) (int, error) {
-	return c.Log(level, message)
+	SinkLogger
 }
 
 // Creates a new LoggerBuilder for making instances of ConsoleLogger
 func ConsoleLoggerBuilder() LoggerBuilder {
-	return &consoleLoggerBuilder{
-		builder: NewGenericLoggerBuilder(),
-	}
-}
-
-type consoleLoggerBuilder struct {
-	builder *GenericLoggerBuilder
+	return ConsoleLoggerBuilderWithWriter(os.Stdout)
 }
 
-func (b *consoleLoggerBuilder) AddLevel(name string, display func() string) LoggerBuilder {
-	b.builder.AddLevel(name, display)
-	return b
-}
-
-func (b *consoleLoggerBuilder) AddPadding(padding Padding) LoggerBuilder {
-	b.builder.AddPadding(padding)
-	return b
-}
-
-func (b *consoleLoggerBuilder) AddColumn(column Column) LoggerBuilder {
-	b.builder.AddColumn(column)
-	return b
+// Creates a new LoggerBuilder for making instances of ConsoleLogger that write to the writer passed,
+// for example os.Stderr.
+func ConsoleLoggerBuilderWithWriter(writer io.Writer) LoggerBuilder {
+	return &sinkLoggerBuilder{
+		builder: NewGenericLoggerBuilder(),
+		sink:    writerSink{writer: writer},
+		wrap: func(logger SinkLogger) Logger {
+			return &ConsoleLogger{logger}
+		},
+	}
 }
 
-func (b *consoleLoggerBuilder) AddColumnByIndex(index uint, column Column) (LoggerBuilder, error) {
-	_, err := b.builder.AddColumnByIndex(index, column)
-	return b, err
+// A Sink that writes every line to an io.Writer.
+type writerSink struct {
+	writer io.Writer
 }
 
-func (b *consoleLoggerBuilder) Build() Logger {
-	paddings := make([]Padding, 0)
-
-	for key := range b.builder.Paddings {
-		paddings = append(paddings, key)
+func (w writerSink) Write(context Context, line string) error {
+	writer := w.writer
+	if writer == nil {
+		writer = os.Stdout
 	}
+	_, err := fmt.Fprintln(writer, line)
+	return err
+}
 
-	return &ConsoleLogger{
-		levels:   b.builder.Levels,
-		paddings: paddings,
-		columns:  b.builder.Columns,
-	}
+// The writer isn't closed, since it's usually os.Stdout or os.Stderr.
+func (w writerSink) Close() error {
+	return nil
 }
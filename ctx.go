@@ -0,0 +1,103 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"context"
+)
+
+// Pulls Fields out of a context.Context, such as trace or request IDs, see LoggerBuilder.AddExtractor.
+type Extractor func(ctx context.Context) map[string]interface{}
+
+type loggerKey struct{}
+
+type fieldsKey struct{}
+
+// Returns a copy of ctx that carries logger, see FromContext.
+func WithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// Returns the Logger stored by WithLogger, or the unnamed Logger of DefaultRegistry if there is none.
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(Logger); ok {
+			return logger
+		}
+	}
+	return GetLogger("")
+}
+
+// Returns a copy of ctx that carries fields on top of the ones it already carries. Every message logged
+// with this context through ContextLogger.LogCtx gets the fields added to its Context.Fields.
+func WithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	merged := make(map[string]interface{})
+	for key, value := range FieldsFromContext(ctx) {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// Returns the fields stored by WithFields, or nil if there are none.
+func FieldsFromContext(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).(map[string]interface{})
+	return fields
+}
+
+/*
+	Collects the Fields of a message. Later sources win over earlier ones:
+
+		- The fields stored with WithFields
+		- The Fields from every Extractor, in order
+		- The extra info, when it's a map[string]interface{}
+
+	Returns nil when there are no fields at all.
+*/
+func collectFields(ctx context.Context, extractors []Extractor, info interface{}) map[string]interface{} {
+	infoFields, _ := info.(map[string]interface{})
+	if ctx == nil {
+		return infoFields
+	}
+
+	stored := FieldsFromContext(ctx)
+	if len(stored) == 0 && len(extractors) == 0 {
+		return infoFields
+	}
+
+	fields := make(map[string]interface{}, len(stored)+len(infoFields))
+	for key, value := range stored {
+		fields[key] = value
+	}
+	for _, extractor := range extractors {
+		for key, value := range extractor(ctx) {
+			fields[key] = value
+		}
+	}
+	for key, value := range infoFields {
+		fields[key] = value
+	}
+	return fields
+}
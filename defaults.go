@@ -20,7 +20,9 @@
 package simple_logger
 
 import (
+	"fmt"
 	"github.com/logrusorgru/aurora/v3"
+	"hash/fnv"
 )
 
 var (
@@ -35,3 +37,74 @@ var (
 	// See SetDefaults
 	Fatal = func() string { return aurora.Bold(aurora.Red("FATAL")).String() }
 )
+
+const (
+	// See SetDefaults
+	DebugSeverity = 10
+	// See SetDefaults
+	InfoSeverity = 20
+	// See SetDefaults
+	WarningSeverity = 30
+	// See SetDefaults
+	ErrorSeverity = 40
+	// See SetDefaults
+	FatalSeverity = 50
+)
+
+// The Column s SetDefaults adds, these are also registered under their names for LookupColumn.
+var (
+	timestampColumn Column = func(context Context) string {
+		layout := fmt.Sprintf("%v %v %v, %v @ %v:%v:%v", Weekday, Month, Day, Year, Hour, Minute, Second)
+		return aurora.BrightBlue(context.FormatTimestamp(layout)).String()
+	}
+	levelColumn   Column = func(context Context) string { return context.FormatLevel() }
+	messageColumn Column = func(context Context) string { return context.Message }
+)
+
+// The colours ColorizeName picks from.
+var nameColors = []aurora.Color{
+	aurora.RedFg,
+	aurora.GreenFg,
+	aurora.YellowFg,
+	aurora.BlueFg,
+	aurora.MagentaFg,
+	aurora.CyanFg,
+	aurora.BrightFg | aurora.RedFg,
+	aurora.BrightFg | aurora.GreenFg,
+	aurora.BrightFg | aurora.YellowFg,
+	aurora.BrightFg | aurora.BlueFg,
+	aurora.BrightFg | aurora.MagentaFg,
+	aurora.BrightFg | aurora.CyanFg,
+}
+
+// Colours a name by its hash, so the same name always gets the same colour.
+func ColorizeName(name string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	return aurora.Colorize(name, nameColors[hash.Sum32()%uint32(len(nameColors))]).String()
+}
+
+// Renders the name of the Logger coloured with ColorizeName, this is registered as NameColumn.
+// Logger s without a name render an empty column.
+var NameColumnFunc Column = func(context Context) string {
+	if context.Name == "" {
+		return ""
+	}
+	return ColorizeName(context.Name)
+}
+
+var (
+	// Renders the trace ID stored under TraceIDField, see TraceExtractor. This is registered as TraceColumn.
+	TraceIDColumnFunc Column = func(context Context) string { return fieldString(context, TraceIDField) }
+	// Renders the span ID stored under SpanIDField, see TraceExtractor. This is registered as SpanColumn.
+	SpanIDColumnFunc Column = func(context Context) string { return fieldString(context, SpanIDField) }
+)
+
+// Returns a field of the Context as a string, or an empty string if it isn't set.
+func fieldString(context Context, key string) string {
+	value, ok := context.Fields[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Turns a logged message into the line a Logger writes. The columns passed are the output of every Column
+// of the Logger, in order.
+type Encoder func(context Context, columns []string) string
+
+var (
+	/*
+		Joins the columns with a pipe, this is the default Encoder:
+
+			Saturday August 29, 2020 @ 5:41:00 | INFO | Hello, world
+	*/
+	TextEncoder Encoder = func(context Context, columns []string) string {
+		format := ""
+		for index, column := range columns {
+			pad := ""
+			if index != 0 {
+				pad = " "
+			}
+
+			end := ""
+			if index != len(columns)-1 {
+				end = " |"
+			}
+
+			format += fmt.Sprintf("%v%v%v", pad, column, end)
+		}
+		return format
+	}
+
+	/*
+		Ignores the columns and writes the message as a single JSON object, with the Fields of the Context
+		added alongside:
+
+			{"level":"INFO","message":"Hello, world","time":"2020-08-29T05:41:00.000000000-04:00"}
+
+		When the extra info passed to Logger.LogWithExtraInfo isn't a map it's added under "info".
+	*/
+	JSONEncoder Encoder = func(context Context, columns []string) string {
+		entry := make(map[string]interface{}, len(context.Fields)+4)
+		for key, value := range context.Fields {
+			entry[key] = value
+		}
+		if _, ok := context.Info.(map[string]interface{}); !ok && context.Info != nil {
+			entry["info"] = context.Info
+		}
+		entry["time"] = context.Time.Format(time.RFC3339Nano)
+		entry["level"] = context.Level
+		entry["message"] = context.Message
+
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			delete(entry, "info")
+			for key := range context.Fields {
+				entry[key] = fmt.Sprintf("%v", context.Fields[key])
+			}
+			encoded, _ = json.Marshal(entry)
+		}
+		return string(encoded)
+	}
+)
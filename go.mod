@@ -2,4 +2,8 @@ module github.com/xaanit/simple-logger
 
 go 1.13
 
-require github.com/logrusorgru/aurora/v3 v3.0.0
+require (
+	github.com/BurntSushi/toml v0.3.1
+	github.com/logrusorgru/aurora/v3 v3.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
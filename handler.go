@@ -0,0 +1,205 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+	An http.Handler that exposes the minimum level and enabled levels of registered Logger s as JSON,
+	so verbosity can be changed while the program is running. Mount it with http.StripPrefix if it isn't
+	served from the root:
+
+		GET  /            returns the levels of every registered Logger, by name
+		GET  /{name}      returns the levels of one Logger
+		PUT  /{name}      changes the levels of one Logger, e.g. {"min_level": "DEBUG"}
+
+	A PUT may set "min_level", "enabled_levels" or both. Levels left out of "enabled_levels" are disabled.
+	Whether a level is enabled doesn't depend on the minimum level, so a GET response can be PUT back as it is.
+*/
+type LevelHandler struct {
+	loggers map[string]ReconfigurableLogger
+	mutex   sync.RWMutex
+}
+
+// The JSON body LevelHandler reads and writes.
+type LevelStatus struct {
+	MinLevel string   `json:"min_level"`
+	Levels   []string `json:"levels"`
+	// The levels that aren't disabled, whether or not they're below the minimum level.
+	EnabledLevels  []string `json:"enabled_levels"`
+	DisabledLevels []string `json:"disabled_levels"`
+}
+
+type levelUpdate struct {
+	MinLevel      *string   `json:"min_level"`
+	EnabledLevels *[]string `json:"enabled_levels"`
+}
+
+// Makes a LevelHandler with no Logger s registered.
+func NewLevelHandler() *LevelHandler {
+	return &LevelHandler{
+		loggers: make(map[string]ReconfigurableLogger),
+	}
+}
+
+// Registers a Logger under a name. This errors if the Logger can't be reconfigured, which is never
+// the case for Logger s built by this package.
+func (h *LevelHandler) Register(name string, logger Logger) error {
+	reconfigurable, ok := logger.(ReconfigurableLogger)
+	if !ok {
+		return fmt.Errorf("logger %v can't be reconfigured", name)
+	}
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("logger name %v must not contain a slash", name)
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.loggers[name] = reconfigurable
+	return nil
+}
+
+// Removes the Logger registered under the name passed.
+func (h *LevelHandler) Unregister(name string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.loggers, name)
+}
+
+// Implements http.Handler
+func (h *LevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.Trim(r.URL.Path, "/")
+
+	if name == "" {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		h.mutex.RLock()
+		statuses := make(map[string]LevelStatus, len(h.loggers))
+		for name, logger := range h.loggers {
+			statuses[name] = levelStatus(logger)
+		}
+		h.mutex.RUnlock()
+
+		writeJSON(w, statuses)
+		return
+	}
+
+	h.mutex.RLock()
+	logger, ok := h.loggers[name]
+	h.mutex.RUnlock()
+	if !ok {
+		http.Error(w, fmt.Sprintf("there is no logger named %v", name), http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, levelStatus(logger))
+	case http.MethodPut:
+		update := levelUpdate{}
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			http.Error(w, fmt.Sprintf("invalid body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if err := applyLevelUpdate(logger, update); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, levelStatus(logger))
+	default:
+		w.Header().Set("Allow", fmt.Sprintf("%v, %v", http.MethodGet, http.MethodPut))
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func levelStatus(logger ReconfigurableLogger) LevelStatus {
+	status := LevelStatus{
+		MinLevel:       logger.GetMinLevel(),
+		Levels:         make([]string, 0),
+		EnabledLevels:  make([]string, 0),
+		DisabledLevels: make([]string, 0),
+	}
+
+	for name := range logger.GetLevels() {
+		status.Levels = append(status.Levels, name)
+	}
+	sort.Strings(status.Levels)
+
+	disabled := func(name string) bool { return !logger.IsLevelEnabled(name) }
+	if configured, ok := logger.(configuredLogger); ok {
+		levels := configured.configuration().DisabledLevels
+		disabled = func(name string) bool {
+			_, ok := levels[name]
+			return ok
+		}
+	}
+	for _, name := range status.Levels {
+		if disabled(name) {
+			status.DisabledLevels = append(status.DisabledLevels, name)
+		} else {
+			status.EnabledLevels = append(status.EnabledLevels, name)
+		}
+	}
+	return status
+}
+
+func applyLevelUpdate(logger ReconfigurableLogger, update levelUpdate) error {
+	if update.EnabledLevels != nil {
+		levels := logger.GetLevels()
+		for _, name := range *update.EnabledLevels {
+			if _, ok := levels[name]; !ok {
+				return fmt.Errorf("%v is not a valid level for this Logger", name)
+			}
+		}
+	}
+
+	return logger.Reconfigure(func(builder LoggerBuilder) {
+		if update.MinLevel != nil {
+			builder.SetMinLevel(*update.MinLevel)
+		}
+
+		if update.EnabledLevels != nil {
+			for _, name := range builder.ListLevels() {
+				if findStrings(*update.EnabledLevels, name) == -1 {
+					builder.DisableLevel(name)
+				} else {
+					builder.EnableLevel(name)
+				}
+			}
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+// Intercepts the messages of a Logger, see LoggerBuilder.AddHook. Hooks can redact or enrich a message,
+// drop it, or forward it somewhere else such as metrics.
+type Hook interface {
+	// Called before any Column is evaluated. Changes made to the Context are what the Column s, the Encoder
+	// and the Logger see. Returning false aborts the write.
+	Before(context *Context) bool
+	// Called after the line was written, with the error from writing it if there was one.
+	After(context Context, line string, err error)
+}
+
+// Makes a Hook out of functions, either of which may be nil.
+type HookFuncs struct {
+	BeforeFunc func(context *Context) bool
+	AfterFunc  func(context Context, line string, err error)
+}
+
+// Implements Hook.Before
+func (h HookFuncs) Before(context *Context) bool {
+	if h.BeforeFunc == nil {
+		return true
+	}
+	return h.BeforeFunc(context)
+}
+
+// Implements Hook.After
+func (h HookFuncs) After(context Context, line string, err error) {
+	if h.AfterFunc != nil {
+		h.AfterFunc(context, line, err)
+	}
+}
+
+// A Hook and the levels it's called for. No levels means every level.
+type LevelHook struct {
+	Hook   Hook
+	Levels []string
+}
+
+// Returns whether the hook is called for the level passed.
+func (h LevelHook) matches(level string) bool {
+	return len(h.Levels) == 0 || findStrings(h.Levels, level) != -1
+}
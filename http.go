@@ -0,0 +1,446 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Configures where and how a HTTPSink ships its batches.
+type HTTPConfig struct {
+	// The endpoint batches are POSTed to.
+	URL string
+	// Sent with every request, e.g. "Authorization".
+	Headers map[string]string
+	// How many lines a batch holds before it's sent. Defaults to 100.
+	BatchSize int
+	// How long a line waits for its batch to fill up before the batch is sent anyway. Defaults to 1 second.
+	BatchInterval time.Duration
+	// Compresses the body with gzip and sets Content-Encoding.
+	Gzip bool
+	// How many times a batch is sent again after a 5xx response or a network error. Defaults to 3, negative
+	// disables retrying.
+	MaxRetries int
+	// How long to wait before the first retry, doubled for every retry after. Defaults to 500 milliseconds.
+	RetryBackoff time.Duration
+	// Defaults to a http.Client with a 10 second timeout.
+	Client *http.Client
+	// How a batch is turned into the body of a request. Defaults to NDJSONPayload.
+	Payload Payload
+	// Called with the error of every batch that couldn't be shipped or was dropped, nil ignores them.
+	OnError func(err error)
+	// How many full batches may wait to be shipped, further batches are dropped. Defaults to 100.
+	MaxQueuedBatches int
+	// How long Close waits for the batches left to be shipped before dropping them. Defaults to 10 seconds.
+	CloseTimeout time.Duration
+	// Where full batches wait to be shipped instead of memory, e.g. a NewDiskBuffer so they survive restarts.
+	// Batches stay in it until they're shipped, however often that fails, and are left in it by Close.
+	// MaxQueuedBatches doesn't apply, batches are dropped once the Buffer doesn't take them.
+	Buffer Buffer
+}
+
+// Counts what a HTTPSink did with the lines it was given.
+type HTTPStats struct {
+	// Lines that were shipped.
+	Shipped uint64
+	// Lines that couldn't be shipped, either because the endpoint kept failing or because they were dropped.
+	Dropped uint64
+	// Full batches waiting to be shipped right now, including those in HTTPConfig.Buffer.
+	Queued int
+}
+
+/*
+	A Sink that collects lines into batches and POSTs them, by default as NDJSON with one line per line of the body.
+	The lines should be JSON, HTTPLoggerBuilder sets JSONEncoder for that. See HTTPConfig.Payload for other formats.
+
+	Batches are sent from a goroutine of their own, so logging doesn't wait for the endpoint. Up to
+	MaxQueuedBatches full batches queue up while the endpoint is slow or down, after that batches are dropped.
+	Close sends whatever hasn't been sent yet and waits for it, for at most CloseTimeout.
+	See HTTPConfig.Buffer for keeping batches until they're shipped instead.
+*/
+type HTTPSink struct {
+	config HTTPConfig
+	mutex  sync.Mutex
+	batch  []string
+	// Full batches waiting to be shipped, oldest first.
+	queue   [][]string
+	closed  bool
+	wake    chan struct{}
+	done    chan struct{}
+	shipped uint64
+	dropped uint64
+	// Cancelled once Close gives up, which stops the request in flight and the retries.
+	abort  context.Context
+	cancel context.CancelFunc
+}
+
+// Creates a HTTPSink and starts the goroutine that sends its batches.
+func NewHTTPSink(config HTTPConfig) *HTTPSink {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.BatchInterval <= 0 {
+		config.BatchInterval = time.Second
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 500 * time.Millisecond
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if config.Payload.Encode == nil {
+		config.Payload = NDJSONPayload
+	}
+	if config.MaxQueuedBatches <= 0 {
+		config.MaxQueuedBatches = 100
+	}
+	if config.CloseTimeout <= 0 {
+		config.CloseTimeout = 10 * time.Second
+	}
+
+	abort, cancel := context.WithCancel(context.Background())
+	sink := &HTTPSink{
+		config: config,
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		abort:  abort,
+		cancel: cancel,
+	}
+	go sink.run()
+	if config.Buffer != nil && config.Buffer.Len() > 0 {
+		// Ships what was left from before.
+		sink.signal()
+	}
+	return sink
+}
+
+// Creates a new LoggerBuilder for making instances of SinkLogger that ship to a NewHTTPSink, with JSONEncoder set.
+func HTTPLoggerBuilder(config HTTPConfig) LoggerBuilder {
+	return SinkLoggerBuilder(NewHTTPSink(config)).SetEncoder(JSONEncoder)
+}
+
+// Returns what the HTTPSink did so far.
+func (h *HTTPSink) Stats() HTTPStats {
+	h.mutex.Lock()
+	queued := len(h.queue)
+	if h.config.Buffer != nil {
+		queued = h.config.Buffer.Len()
+	}
+	h.mutex.Unlock()
+
+	return HTTPStats{
+		Shipped: atomic.LoadUint64(&h.shipped),
+		Dropped: atomic.LoadUint64(&h.dropped),
+		Queued:  queued,
+	}
+}
+
+// Implements Sink.Write, the line is added to the current batch. Returns an error when the batch was full
+// and there was no room left to queue it.
+func (h *HTTPSink) Write(context Context, line string) error {
+	h.mutex.Lock()
+	if h.closed {
+		h.mutex.Unlock()
+		return errors.New("the sink is closed")
+	}
+
+	h.batch = append(h.batch, line)
+	var err error
+	full := len(h.batch) >= h.config.BatchSize
+	if full {
+		err = h.enqueue()
+	}
+	h.mutex.Unlock()
+
+	if err != nil {
+		return h.drop(h.config.BatchSize, err)
+	}
+	if full {
+		h.signal()
+	}
+	return nil
+}
+
+// Moves the current batch to the queue, or the Buffer when there is one. Returns an error when it was dropped.
+func (h *HTTPSink) enqueue() error {
+	batch := h.batch
+	h.batch = nil
+
+	if h.config.Buffer != nil {
+		encoded, err := json.Marshal(batch)
+		if err == nil {
+			err = h.config.Buffer.Push(string(encoded))
+		}
+		if err != nil {
+			return fmt.Errorf("dropped %v lines: %v", len(batch), err)
+		}
+		return nil
+	}
+
+	if len(h.queue) >= h.config.MaxQueuedBatches {
+		return fmt.Errorf("dropped %v lines, too many batches are waiting to be shipped", len(batch))
+	}
+	h.queue = append(h.queue, batch)
+	return nil
+}
+
+// Counts and reports lines that won't be shipped, and returns err.
+func (h *HTTPSink) drop(lines int, err error) error {
+	atomic.AddUint64(&h.dropped, uint64(lines))
+	if h.config.OnError != nil {
+		h.config.OnError(err)
+	}
+	return err
+}
+
+/*
+	Implements Sink.Close, sends what's left and waits for every batch to be shipped.
+
+	Returns an error when the batches left couldn't be shipped within CloseTimeout, they're dropped.
+*/
+func (h *HTTPSink) Close() error {
+	h.mutex.Lock()
+	if h.closed {
+		h.mutex.Unlock()
+		return nil
+	}
+
+	h.closed = true
+	var err error
+	dropped := len(h.batch)
+	if dropped > 0 {
+		err = h.enqueue()
+	}
+	h.mutex.Unlock()
+
+	if err != nil {
+		_ = h.drop(dropped, err)
+	}
+	h.signal()
+
+	timer := time.NewTimer(h.config.CloseTimeout)
+	defer timer.Stop()
+	select {
+	case <-h.done:
+	case <-timer.C:
+		err = fmt.Errorf("gave up shipping the batches left after %v", h.config.CloseTimeout)
+		h.cancel()
+		<-h.done
+	}
+	h.cancel()
+
+	if h.config.Buffer != nil {
+		if closeErr := h.config.Buffer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Wakes up the goroutine shipping the batches, without waiting for it.
+func (h *HTTPSink) signal() {
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (h *HTTPSink) run() {
+	defer close(h.done)
+	ticker := time.NewTicker(h.config.BatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.wake:
+		case <-ticker.C:
+			h.mutex.Lock()
+			var err error
+			dropped := len(h.batch)
+			if dropped > 0 {
+				err = h.enqueue()
+			}
+			h.mutex.Unlock()
+
+			if err != nil {
+				_ = h.drop(dropped, err)
+			}
+		}
+
+		if h.config.Buffer != nil {
+			h.shipBuffer()
+		}
+
+		h.mutex.Lock()
+		queue, closed := h.queue, h.closed
+		h.queue = nil
+		h.mutex.Unlock()
+
+		for _, batch := range queue {
+			if h.abort.Err() != nil {
+				_ = h.drop(len(batch), fmt.Errorf("could not ship %v lines before the sink was closed", len(batch)))
+				continue
+			}
+			h.ship(batch)
+		}
+		if closed {
+			// Nothing is added after the sink is closed, so this was the last of it.
+			return
+		}
+	}
+}
+
+// Ships the batches in the Buffer, oldest first. Stops at the first batch that can't be shipped,
+// it's tried again the next time.
+func (h *HTTPSink) shipBuffer() {
+	for h.abort.Err() == nil {
+		h.mutex.Lock()
+		encoded, ok := h.config.Buffer.Peek()
+		h.mutex.Unlock()
+		if !ok {
+			return
+		}
+
+		var batch []string
+		if err := json.Unmarshal([]byte(encoded), &batch); err != nil {
+			_ = h.drop(0, fmt.Errorf("dropped a batch that couldn't be read back: %v", err))
+		} else if retry, err := h.send(batch); retry {
+			if h.config.OnError != nil {
+				h.config.OnError(fmt.Errorf("could not ship %v lines, they're kept to try again: %v", len(batch), err))
+			}
+			return
+		} else if err != nil {
+			// The endpoint won't take them however often they're sent.
+			_ = h.drop(len(batch), fmt.Errorf("could not ship %v lines: %v", len(batch), err))
+		} else {
+			atomic.AddUint64(&h.shipped, uint64(len(batch)))
+		}
+
+		h.mutex.Lock()
+		err := h.config.Buffer.Pop()
+		h.mutex.Unlock()
+		if err != nil {
+			if h.config.OnError != nil {
+				h.config.OnError(err)
+			}
+			return
+		}
+	}
+}
+
+// Sends a batch, counting it as shipped or dropped.
+func (h *HTTPSink) ship(batch []string) {
+	if _, err := h.send(batch); err != nil {
+		_ = h.drop(len(batch), fmt.Errorf("could not ship %v lines: %v", len(batch), err))
+		return
+	}
+	atomic.AddUint64(&h.shipped, uint64(len(batch)))
+}
+
+// Sends a batch, retrying on network errors and 5xx responses. Returns whether it's worth trying again later
+// along with the error.
+func (h *HTTPSink) send(batch []string) (bool, error) {
+	retry := false
+	body, err := h.body(batch)
+	if err == nil {
+		backoff := h.config.RetryBackoff
+		for attempt := 0; ; attempt++ {
+			if retry, err = h.post(body); !retry || attempt >= h.config.MaxRetries {
+				break
+			}
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-h.abort.Done():
+				timer.Stop()
+			}
+			if h.abort.Err() != nil {
+				break
+			}
+			backoff *= 2
+		}
+	}
+	return retry, err
+}
+
+func (h *HTTPSink) body(batch []string) ([]byte, error) {
+	payload, err := h.config.Payload.Encode(batch)
+	if err != nil || !h.config.Gzip {
+		return payload, err
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// Returns whether the request is worth retrying along with the error.
+func (h *HTTPSink) post(body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(h.abort, http.MethodPost, h.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	request.Header.Set("Content-Type", h.config.Payload.ContentType)
+	if h.config.Gzip {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
+	for key, value := range h.config.Headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := h.config.Client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	_ = response.Body.Close()
+
+	switch {
+	case response.StatusCode >= 500:
+		return true, fmt.Errorf("%v responded with %v", h.config.URL, response.Status)
+	case response.StatusCode >= 300:
+		return false, fmt.Errorf("%v responded with %v", h.config.URL, response.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Where journald listens for entries in its native protocol.
+const JournalSocket = "/run/systemd/journal/socket"
+
+// Configures how a journald sink sends its entries.
+type JournalConfig struct {
+	// The socket to send to. Defaults to JournalSocket.
+	Socket string
+	// Sent as SYSLOG_IDENTIFIER. Defaults to the name of the executable.
+	Identifier string
+	// The syslog severity of every level, sent as PRIORITY, see SyslogConfig.Severities.
+	Severities map[string]int
+	// Where lines are written when the socket doesn't exist. Defaults to os.Stdout.
+	Fallback io.Writer
+}
+
+/*
+	Creates a new LoggerBuilder for making instances of SinkLogger that send structured entries to journald.
+	Entries too large for a single datagram are passed to journald as a file descriptor instead.
+
+	Every entry has
+		- MESSAGE, the message that was logged
+		- PRIORITY, the syslog severity of the level
+		- SYSLOG_IDENTIFIER, see JournalConfig.Identifier
+		- LOGGER, the name of the Logger, when it has one
+		- every one of Context.Fields, with the key uppercased and anything but letters, digits and
+		  underscores replaced by underscores. Keys that would be one of the fields above are prefixed with
+		  FIELD_, and keys that end up longer than 64 characters are left out
+
+	When the socket doesn't exist, e.g. the host doesn't run systemd, the rendered lines are written to
+	JournalConfig.Fallback instead, the same way a ConsoleLogger would.
+*/
+func JournalLoggerBuilder(config JournalConfig) LoggerBuilder {
+	if config.Socket == "" {
+		config.Socket = JournalSocket
+	}
+	if config.Identifier == "" {
+		config.Identifier = filepath.Base(os.Args[0])
+	}
+	return SinkLoggerBuilder(&journalSink{
+		config:   config,
+		fallback: writerSink{writer: config.Fallback},
+	})
+}
+
+type journalSink struct {
+	config   JournalConfig
+	conn     net.Conn
+	fallback writerSink
+}
+
+func (j *journalSink) Write(context Context, line string) error {
+	if j.conn == nil {
+		if _, err := os.Stat(j.config.Socket); err != nil {
+			return j.fallback.Write(context, line)
+		}
+
+		conn, err := net.Dial("unixgram", j.config.Socket)
+		if err != nil {
+			return err
+		}
+		j.conn = conn
+	}
+
+	entry := j.entry(context)
+	_, err := j.conn.Write(entry)
+	if isMessageTooLong(err) {
+		// Entries that don't fit in a datagram are passed as a file instead, like sd_journal_send does.
+		err = sendJournalFile(j.config.Socket, entry)
+	}
+	if err != nil {
+		_ = j.conn.Close()
+		j.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (j *journalSink) Close() error {
+	if j.conn == nil {
+		return nil
+	}
+	err := j.conn.Close()
+	j.conn = nil
+	return err
+}
+
+// Serializes the Context in the native journal protocol.
+func (j *journalSink) entry(context Context) []byte {
+	var entry bytes.Buffer
+	writeJournalField(&entry, "MESSAGE", context.Message)
+	writeJournalField(&entry, "PRIORITY", fmt.Sprintf("%v", syslogSeverity(context, j.config.Severities)))
+	writeJournalField(&entry, "SYSLOG_IDENTIFIER", j.config.Identifier)
+	if context.Name != "" {
+		writeJournalField(&entry, "LOGGER", context.Name)
+	}
+
+	keys := make([]string, 0, len(context.Fields))
+	for key := range context.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := journalFieldName(key)
+		if name == "" {
+			continue
+		}
+		if _, ok := reservedJournalFields[name]; ok {
+			name = "FIELD_" + name
+		}
+		if len(name) > maxJournalFieldName {
+			continue
+		}
+		writeJournalField(&entry, name, fieldString(context, key))
+	}
+	return entry.Bytes()
+}
+
+// Writes KEY=value, or for values with a newline, KEY, the length as a little endian uint64 and the value.
+func writeJournalField(entry *bytes.Buffer, name, value string) {
+	entry.WriteString(name)
+	if !strings.Contains(value, "\n") {
+		entry.WriteByte('=')
+		entry.WriteString(value)
+		entry.WriteByte('\n')
+		return
+	}
+
+	entry.WriteByte('\n')
+	_ = binary.Write(entry, binary.LittleEndian, uint64(len(value)))
+	entry.WriteString(value)
+	entry.WriteByte('\n')
+}
+
+// The fields every entry has, Fields with these names are prefixed with FIELD_ so they don't replace them.
+var reservedJournalFields = map[string]interface{}{
+	"MESSAGE":           nil,
+	"PRIORITY":          nil,
+	"SYSLOG_IDENTIFIER": nil,
+	"LOGGER":            nil,
+}
+
+// journald drops fields with longer names.
+const maxJournalFieldName = 64
+
+// Turns a field key into a journal field name, journald only accepts uppercase letters, digits and underscores
+// and reserves names starting with an underscore. Returns an empty string for keys that can't be turned into one.
+func journalFieldName(key string) string {
+	name := []rune(strings.ToUpper(key))
+	for index, char := range name {
+		if (char < 'A' || char > 'Z') && (char < '0' || char > '9') {
+			name[index] = '_'
+		}
+	}
+
+	trimmed := strings.TrimLeft(string(name), "_")
+	if trimmed == "" || (trimmed[0] >= '0' && trimmed[0] <= '9') {
+		return ""
+	}
+	return trimmed
+}
@@ -20,8 +20,10 @@
 package simple_logger
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strconv"
 	"time"
 )
 
@@ -42,6 +44,21 @@ const (
 // Represents a column in a logged message.
 type Column func(context Context) string
 
+const (
+	// The name NameColumnFunc is registered under for LookupColumn. It isn't added by SetDefaults.
+	NameColumn = "name"
+	// The name TraceIDColumnFunc is registered under for LookupColumn. It isn't added by SetDefaults.
+	TraceColumn = TraceIDField
+	// The name SpanIDColumnFunc is registered under for LookupColumn. It isn't added by SetDefaults.
+	SpanColumn = SpanIDField
+	// The name SetDefaults registers its timestamp Column under
+	TimestampColumn = "timestamp"
+	// The name SetDefaults registers its level Column under
+	LevelColumn = "level"
+	// The name SetDefaults registers its message Column under
+	MessageColumn = "message"
+)
+
 // Logger stuff
 
 const (
@@ -51,6 +68,14 @@ const (
 	InvalidLevel
 	// There were no Column s set in the Logger
 	NoColumnsSet
+	// The level passed is below the minimum level of the Logger, or disabled, so nothing was logged
+	BelowMinLevel
+	// The message was dropped by sampling or rate limiting
+	Sampled
+	// A Hook aborted the write
+	Aborted
+	// The line couldn't be written, the error says why
+	WriteFailed
 )
 
 // Represents a Logger that can log to a variety of things.
@@ -67,6 +92,36 @@ type Logger interface {
 	LogWithExtraInfo(level, message string, info interface{}) (int, error)
 }
 
+// Represents a Logger that can log with a context.Context, see LoggerBuilder.AddExtractor and WithFields.
+type ContextLogger interface {
+	Logger
+	// Same as Logger.Log, but the Fields of the Context are filled in from ctx.
+	LogCtx(ctx context.Context, level, message string) (int, error)
+	// Same as Logger.LogWithExtraInfo, but the Fields of the Context are filled in from ctx.
+	LogCtxWithExtraInfo(ctx context.Context, level, message string, info interface{}) (int, error)
+}
+
+// Represents a Logger whose configuration can be changed after it has been built.
+type ReconfigurableLogger interface {
+	Logger
+	// Returns the name of this Logger, see LoggerBuilder.SetName.
+	GetName() string
+	// Returns a Logger that shares the configuration of this one, reconfiguring either reconfigures both,
+	// but whose name has the component appended, e.g. "api" becomes "api.auth".
+	Named(component string) ReconfigurableLogger
+	// Returns the severity of every level that has one.
+	GetSeverities() map[string]int
+	// Returns the minimum level this Logger logs, or an empty string if it logs every level.
+	GetMinLevel() string
+	// Returns whether a message logged at the level passed would be written, taking the minimum level
+	// and disabled levels into account.
+	IsLevelEnabled(level string) bool
+	// Calls configure with a builder holding the current configuration. Once configure returns the result is
+	// validated and swapped in atomically, so goroutines logging at the same time see either the old or the new
+	// configuration. Nothing changes if the result doesn't validate.
+	Reconfigure(configure func(builder LoggerBuilder)) error
+}
+
 // Context stuff
 
 const ( // date formatting
@@ -83,13 +138,54 @@ const ( // Time formatting
 	Second = "05"
 )
 
+const ( // Layout presets
+	RFC3339     = time.RFC3339
+	RFC3339Nano = time.RFC3339Nano
+	// ISO-8601 with milliseconds, e.g. 2020-08-29T05:41:00.000Z
+	ISO8601Millis = "2006-01-02T15:04:05.000Z07:00"
+	// Seconds since the Unix epoch, e.g. 1598679660. Only understood by the Format methods of Context.
+	UnixSeconds = "unix"
+	// Milliseconds since the Unix epoch, e.g. 1598679660000. Only understood by the Format methods of Context.
+	UnixMillis = "unixmillis"
+)
+
+// Formats t with the layout, which may be one of the Unix presets.
+func formatTime(t time.Time, layout string) string {
+	switch layout {
+	case UnixSeconds:
+		return strconv.FormatInt(t.Unix(), 10)
+	case UnixMillis:
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	}
+	return t.Format(layout)
+}
+
 // Represents the Context of a Logger message. This contains the Message being sent,
-// the Time of the message, it's Level, and the corresponding Logger.
+// the Time of the message, it's Level, and the corresponding Logger and its Name.
+//
+// Info holds the extra info passed to Logger.LogWithExtraInfo. Fields holds the structured fields of the message
+// for Encoder s to use: the extra info when it's a map[string]interface{}, plus whatever was pulled out of Ctx
+// when the message was logged through ContextLogger.LogCtx.
 type Context struct {
+	Ctx     context.Context
+	Name    string
 	Message string
 	Time    time.Time
 	Level   string
 	Logger  Logger
+	Info    interface{}
+	Fields  map[string]interface{}
+}
+
+// Joins a name and a component with a dot, leaving out whichever is empty.
+func joinName(name, component string) string {
+	if name == "" {
+		return component
+	}
+	if component == "" {
+		return name
+	}
+	return name + "." + component
 }
 
 // ANSI color codes
@@ -137,7 +233,7 @@ func (c *Context) FormatLevel() string {
 func (c *Context) FormatDate(layout string) string {
 	padding := findPadding(c.Logger.GetPaddings(), DatePadding) != -1
 	after := ""
-	formatted := c.Time.Format(layout)
+	formatted := formatTime(c.Time, layout)
 
 	if padding {
 		longest := 30 // "Wednesday September 30th, 9999" was the longest date I could find.
@@ -151,7 +247,7 @@ func (c *Context) FormatDate(layout string) string {
 
 // Formats the time with the layout provided.
 func (c *Context) FormatTime(layout string) string {
-	return c.Time.Format(layout)
+	return formatTime(c.Time, layout)
 }
 
 var longestTimestampSeen = 0
@@ -162,7 +258,7 @@ var longestTimestampSeen = 0
 */
 func (c *Context) FormatTimestamp(layout string) string {
 	padding := findPadding(c.Logger.GetPaddings(), TimestampPadding) != -1
-	formatted := c.Time.Format(layout)
+	formatted := formatTime(c.Time, layout)
 	after := ""
 
 	if padding {
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+// Helpers for testing code that logs with simple_logger: a RecordingLogger that captures what's logged,
+// assertions on it, golden files and a Clock that only moves when told to.
+package logtest
+
+import (
+	log "github.com/xaanit/simple-logger"
+	"time"
+)
+
+// The time a Clock from NewClock starts at when passed the zero time.
+var DefaultTime = time.Date(2020, time.August, 29, 5, 41, 0, 0, time.UTC)
+
+// A log.FakeClock, so timestamps in logged lines are the same on every run.
+type Clock = log.FakeClock
+
+// Creates a Clock at start, DefaultTime when it's zero, that moves forward by step every time it's read.
+func NewClock(start time.Time, step time.Duration) *Clock {
+	if start.IsZero() {
+		start = DefaultTime
+	}
+
+	clock := log.NewFakeClock(start)
+	clock.SetStep(step)
+	return clock
+}
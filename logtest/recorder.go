@@ -0,0 +1,148 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package logtest
+
+import (
+	log "github.com/xaanit/simple-logger"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var ansi = regexp.MustCompile("\\x1B(?:[@-Z\\\\-_]|\\[[0-?]*[ -/]*[@-~])")
+
+// Removes ANSI color codes, e.g. the colors of the default levels.
+func StripANSI(line string) string {
+	return ansi.ReplaceAllString(line, "")
+}
+
+/*
+	A Logger that captures the entries and lines it's logged instead of writing them anywhere.
+	It's configured like the builder it was made from, so the lines are rendered exactly like that Logger would,
+	except for the time of every entry, which is taken from its Clock.
+*/
+type RecordingLogger struct {
+	*log.SinkLogger
+	recorder *recorder
+	clock    *Clock
+}
+
+/*
+	Creates a RecordingLogger configured like builder, with a NewClock at DefaultTime that doesn't move
+	until it's told to. Changing builder afterwards doesn't change the RecordingLogger.
+
+	The configuration isn't validated, like LoggerBuilder.Build. Returns an error when builder wasn't made by
+	simple_logger.
+*/
+func NewRecordingLogger(builder log.LoggerBuilder) (*RecordingLogger, error) {
+	recorder := &recorder{}
+	recording, err := log.SinkLoggerBuilderFrom(builder, recorder)
+	if err != nil {
+		return nil, err
+	}
+
+	clock := NewClock(time.Time{}, 0)
+	recording.SetClock(clock)
+
+	return &RecordingLogger{SinkLogger: recording.Build().(*log.SinkLogger), recorder: recorder, clock: clock}, nil
+}
+
+// Returns the Clock the times of the entries are taken from.
+func (r *RecordingLogger) Clock() *Clock {
+	return r.clock
+}
+
+// Returns every entry logged so far, oldest first.
+func (r *RecordingLogger) Entries() []log.Entry {
+	r.recorder.mutex.Lock()
+	defer r.recorder.mutex.Unlock()
+	return append([]log.Entry(nil), r.recorder.entries...)
+}
+
+// Returns every line logged so far, oldest first, with colors stripped.
+func (r *RecordingLogger) Lines() []string {
+	entries := r.Entries()
+	lines := make([]string, len(entries))
+	for index, entry := range entries {
+		lines[index] = StripANSI(entry.Line)
+	}
+	return lines
+}
+
+// Forgets everything logged so far.
+func (r *RecordingLogger) Reset() {
+	r.recorder.mutex.Lock()
+	defer r.recorder.mutex.Unlock()
+	r.recorder.entries = nil
+}
+
+// Fails the test unless an entry at the level was logged whose message contains substring.
+// An empty level matches any level.
+func (r *RecordingLogger) AssertLogged(t testing.TB, level, substring string) {
+	t.Helper()
+	if !r.logged(level, substring) {
+		t.Fatalf("nothing was logged at %q containing %q, logged:\n%v", level, substring, strings.Join(r.Lines(), "\n"))
+	}
+}
+
+// Fails the test if an entry at the level was logged whose message contains substring.
+// An empty level matches any level.
+func (r *RecordingLogger) AssertNotLogged(t testing.TB, level, substring string) {
+	t.Helper()
+	if r.logged(level, substring) {
+		t.Fatalf("something was logged at %q containing %q, logged:\n%v", level, substring, strings.Join(r.Lines(), "\n"))
+	}
+}
+
+func (r *RecordingLogger) logged(level, substring string) bool {
+	for _, entry := range r.Entries() {
+		if (level == "" || entry.Level == level) && strings.Contains(entry.Message, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// The Sink of a RecordingLogger.
+type recorder struct {
+	mutex   sync.Mutex
+	entries []log.Entry
+}
+
+func (r *recorder) Write(context log.Context, line string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.entries = append(r.entries, log.Entry{
+		Level:   context.Level,
+		Time:    context.Time,
+		Name:    context.Name,
+		Message: context.Message,
+		Fields:  context.Fields,
+		Line:    line,
+	})
+	return nil
+}
+
+func (r *recorder) Close() error {
+	return nil
+}
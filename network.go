@@ -0,0 +1,490 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Holds the lines a NetworkSink couldn't send yet, oldest first.
+type Buffer interface {
+	// Adds a line to the end of the Buffer, returns an error when the line was dropped instead.
+	Push(line string) error
+	// Returns the oldest line without removing it.
+	Peek() (string, bool)
+	// Removes the oldest line.
+	Pop() error
+	// Returns how many lines the Buffer holds.
+	Len() int
+	// Releases whatever the Buffer holds on to.
+	Close() error
+}
+
+// Configures where a NetworkSink sends its lines and what it does while the endpoint can't be reached.
+type NetworkConfig struct {
+	// "tcp", "udp", "unix" or "unixgram", and their variants.
+	Network string
+	Address string
+	// Connects with TLS when set. Only for stream networks.
+	TLS *tls.Config
+	// Defaults to 5 seconds.
+	DialTimeout time.Duration
+	// How long to wait before reconnecting after the first failure, doubled after every failure up to
+	// MaxBackoff. Default to 100 milliseconds and 30 seconds.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Where lines go while the endpoint can't be reached. Defaults to a NewMemoryBuffer of 1000 lines.
+	Buffer Buffer
+	// How often the Buffer is sent in the background while lines are waiting in it. Defaults to 1 second.
+	FlushInterval time.Duration
+}
+
+// Counts what a NetworkSink did with the lines it was given.
+type NetworkStats struct {
+	// Lines that were sent, including lines sent from the Buffer.
+	Written uint64
+	// Lines in the Buffer right now.
+	Buffered int
+	// Lines the Buffer dropped.
+	Dropped uint64
+	// Connections that were made after the first one.
+	Reconnects uint64
+}
+
+/*
+	A Sink that sends every line to a socket, one datagram per line on packet networks and newline terminated on
+	stream networks. Set an Encoder like JSONEncoder on the builder to send JSON instead of columns.
+
+	When the endpoint can't be reached lines are kept in the Buffer, and reconnecting is backed off exponentially.
+	A goroutine tries to send the Buffer every FlushInterval, so buffered lines go out even when nothing else is
+	logged. Close stops it.
+*/
+type NetworkSink struct {
+	config     NetworkConfig
+	mutex      sync.Mutex
+	conn       net.Conn
+	connected  bool
+	backoff    time.Duration
+	retryAfter time.Time
+	written    uint64
+	dropped    uint64
+	reconnects uint64
+	closed     bool
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// Creates a NetworkSink, nothing is dialed until the first line is written.
+func NewNetworkSink(config NetworkConfig) *NetworkSink {
+	if config.DialTimeout == 0 {
+		config.DialTimeout = 5 * time.Second
+	}
+	if config.MinBackoff == 0 {
+		config.MinBackoff = 100 * time.Millisecond
+	}
+	if config.MaxBackoff == 0 {
+		config.MaxBackoff = 30 * time.Second
+	}
+	if config.Buffer == nil {
+		config.Buffer = NewMemoryBuffer(1000)
+	}
+	if config.FlushInterval == 0 {
+		config.FlushInterval = time.Second
+	}
+
+	n := &NetworkSink{config: config, stop: make(chan struct{}), done: make(chan struct{})}
+	go n.flushPeriodically()
+	return n
+}
+
+func (n *NetworkSink) flushPeriodically() {
+	ticker := time.NewTicker(n.config.FlushInterval)
+	defer ticker.Stop()
+	defer close(n.done)
+
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+			n.mutex.Lock()
+			if n.config.Buffer.Len() > 0 {
+				_ = n.flush()
+			}
+			n.mutex.Unlock()
+		}
+	}
+}
+
+// Creates a new LoggerBuilder for making instances of SinkLogger that send to a NewNetworkSink.
+func NetworkLoggerBuilder(config NetworkConfig) LoggerBuilder {
+	return SinkLoggerBuilder(NewNetworkSink(config))
+}
+
+// Returns what the NetworkSink did so far.
+func (n *NetworkSink) Stats() NetworkStats {
+	n.mutex.Lock()
+	buffered := n.config.Buffer.Len()
+	n.mutex.Unlock()
+
+	return NetworkStats{
+		Written:    atomic.LoadUint64(&n.written),
+		Buffered:   buffered,
+		Dropped:    atomic.LoadUint64(&n.dropped),
+		Reconnects: atomic.LoadUint64(&n.reconnects),
+	}
+}
+
+// Implements Sink.Write. The line is buffered rather than failing when the endpoint can't be reached,
+// an error is only returned when the Buffer drops it.
+func (n *NetworkSink) Write(context Context, line string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.flush() == nil && n.send(line) == nil {
+		return nil
+	}
+
+	if err := n.config.Buffer.Push(line); err != nil {
+		atomic.AddUint64(&n.dropped, 1)
+		return err
+	}
+	return nil
+}
+
+// Implements Sink.Close. Tries to send what's left in the Buffer once more before closing it.
+// Closing a NetworkSink again does nothing.
+func (n *NetworkSink) Close() error {
+	n.mutex.Lock()
+	if n.closed {
+		n.mutex.Unlock()
+		return nil
+	}
+	n.closed = true
+	n.mutex.Unlock()
+
+	close(n.stop)
+	<-n.done
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.retryAfter = time.Time{}
+	_ = n.flush()
+	if n.conn != nil {
+		_ = n.conn.Close()
+		n.conn = nil
+	}
+	return n.config.Buffer.Close()
+}
+
+// Sends the Buffer, oldest first. Stops at the first line that can't be sent.
+func (n *NetworkSink) flush() error {
+	for n.config.Buffer.Len() > 0 {
+		line, ok := n.config.Buffer.Peek()
+		if !ok {
+			return nil
+		}
+		if err := n.send(line); err != nil {
+			return err
+		}
+		if err := n.config.Buffer.Pop(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *NetworkSink) send(line string) error {
+	if n.conn == nil {
+		if err := n.connect(); err != nil {
+			return err
+		}
+	}
+
+	data := []byte(line)
+	if !isPacketNetwork(n.config.Network) {
+		data = append(data, '\n')
+	}
+
+	if _, err := n.conn.Write(data); err != nil {
+		_ = n.conn.Close()
+		n.conn = nil
+		n.fail()
+		return err
+	}
+	atomic.AddUint64(&n.written, 1)
+	return nil
+}
+
+func (n *NetworkSink) connect() error {
+	if time.Now().Before(n.retryAfter) {
+		return errors.New("waiting to reconnect")
+	}
+
+	dialer := &net.Dialer{Timeout: n.config.DialTimeout}
+	var conn net.Conn
+	var err error
+	if n.config.TLS != nil {
+		conn, err = tls.DialWithDialer(dialer, n.config.Network, n.config.Address, n.config.TLS)
+	} else {
+		conn, err = dialer.Dial(n.config.Network, n.config.Address)
+	}
+
+	if err != nil {
+		n.fail()
+		return err
+	}
+
+	if n.connected {
+		atomic.AddUint64(&n.reconnects, 1)
+	}
+	n.conn = conn
+	n.connected = true
+	n.backoff = 0
+	return nil
+}
+
+// Pushes the next connection attempt back, doubling the backoff each time.
+func (n *NetworkSink) fail() {
+	if n.backoff == 0 {
+		n.backoff = n.config.MinBackoff
+	} else if n.backoff *= 2; n.backoff > n.config.MaxBackoff {
+		n.backoff = n.config.MaxBackoff
+	}
+	n.retryAfter = time.Now().Add(n.backoff)
+}
+
+func isPacketNetwork(network string) bool {
+	switch network {
+	case "udp", "udp4", "udp6", "unixgram":
+		return true
+	}
+	return false
+}
+
+// Creates a Buffer that holds up to capacity lines in memory and drops new lines once it's full.
+func NewMemoryBuffer(capacity int) Buffer {
+	return &memoryBuffer{capacity: capacity}
+}
+
+type memoryBuffer struct {
+	lines    []string
+	capacity int
+}
+
+func (m *memoryBuffer) Push(line string) error {
+	if len(m.lines) >= m.capacity {
+		return errors.New("the buffer is full")
+	}
+	m.lines = append(m.lines, line)
+	return nil
+}
+
+func (m *memoryBuffer) Peek() (string, bool) {
+	if len(m.lines) == 0 {
+		return "", false
+	}
+	return m.lines[0], true
+}
+
+func (m *memoryBuffer) Pop() error {
+	if len(m.lines) > 0 {
+		m.lines = m.lines[1:]
+	}
+	return nil
+}
+
+func (m *memoryBuffer) Len() int {
+	return len(m.lines)
+}
+
+func (m *memoryBuffer) Close() error {
+	return nil
+}
+
+/*
+	Creates a Buffer that keeps up to capacity lines in a file, so they survive the process restarting.
+	Lines already in the file are loaded and sent first. How far the file has been popped is kept next to it
+	in a file with ".offset" appended to path, and the file is compacted once most of it has been popped.
+
+	A last line that was only partly written when the process stopped is dropped, as is anything beyond capacity.
+	A line popped right before the process stopped may be loaded once more.
+
+	Returns an error when the files can't be opened or read.
+*/
+func NewFileBuffer(path string, capacity int) (Buffer, error) {
+	buffer := &fileBuffer{path: path, memoryBuffer: memoryBuffer{capacity: capacity}}
+	if err := buffer.load(); err != nil {
+		_ = buffer.Close()
+		return nil, err
+	}
+	return buffer, nil
+}
+
+// How much of the file has to be popped before it's compacted, as long as that's over half of it.
+const fileBufferCompactSize = 1 << 20
+
+// Keeps the lines in memory as well, every line is appended to the file quoted so it fits on a single line.
+type fileBuffer struct {
+	memoryBuffer
+	path       string
+	file       *os.File
+	offsetFile *os.File
+	// How many bytes each line takes up in the file, including the newline.
+	sizes []int64
+	// Where the first line that hasn't been popped starts, and where the file ends.
+	offset int64
+	end    int64
+}
+
+// Reads the lines after the offset and rewrites the file when lines had to be dropped.
+func (f *fileBuffer) load() error {
+	var err error
+	if f.offsetFile, err = os.OpenFile(f.path+".offset", os.O_CREATE|os.O_RDWR, 0644); err != nil {
+		return err
+	}
+	var offset [8]byte
+	if n, _ := f.offsetFile.ReadAt(offset[:], 0); n == len(offset) {
+		f.offset = int64(binary.LittleEndian.Uint64(offset[:]))
+	}
+
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if f.offset > int64(len(data)) {
+		f.offset = 0
+	}
+
+	dropped := false
+	for rest := data[f.offset:]; len(rest) > 0; {
+		end := bytes.IndexByte(rest, '\n')
+		if end == -1 {
+			// Torn by the process stopping halfway through the write.
+			dropped = true
+			break
+		}
+
+		line, err := strconv.Unquote(string(rest[:end]))
+		if err != nil || len(f.lines) >= f.capacity {
+			dropped = true
+		} else {
+			f.lines = append(f.lines, line)
+			f.sizes = append(f.sizes, int64(end+1))
+		}
+		rest = rest[end+1:]
+	}
+
+	if f.file, err = os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return err
+	}
+	f.end = int64(len(data))
+	if dropped {
+		return f.compact()
+	}
+	return nil
+}
+
+func (f *fileBuffer) Push(line string) error {
+	if err := f.memoryBuffer.Push(line); err != nil {
+		return err
+	}
+
+	record := strconv.Quote(line) + "\n"
+	if _, err := f.file.WriteString(record); err != nil {
+		f.lines = f.lines[:len(f.lines)-1]
+		return err
+	}
+	f.sizes = append(f.sizes, int64(len(record)))
+	f.end += int64(len(record))
+	return nil
+}
+
+func (f *fileBuffer) Pop() error {
+	if len(f.lines) == 0 {
+		return nil
+	}
+
+	_ = f.memoryBuffer.Pop()
+	f.offset += f.sizes[0]
+	f.sizes = f.sizes[1:]
+
+	if len(f.lines) == 0 || (f.offset >= fileBufferCompactSize && f.offset > f.end/2) {
+		return f.compact()
+	}
+	return f.saveOffset()
+}
+
+// Rewrites the file with only the lines that haven't been popped.
+func (f *fileBuffer) compact() error {
+	var data bytes.Buffer
+	for _, line := range f.lines {
+		data.WriteString(strconv.Quote(line) + "\n")
+	}
+
+	temporary := f.path + ".tmp"
+	if err := ioutil.WriteFile(temporary, data.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(temporary, f.path); err != nil {
+		return err
+	}
+
+	var err error
+	if f.file, err = os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return err
+	}
+	f.offset, f.end = 0, int64(data.Len())
+	return f.saveOffset()
+}
+
+func (f *fileBuffer) saveOffset() error {
+	var offset [8]byte
+	binary.LittleEndian.PutUint64(offset[:], uint64(f.offset))
+	_, err := f.offsetFile.WriteAt(offset[:], 0)
+	return err
+}
+
+func (f *fileBuffer) Close() error {
+	var err error
+	for _, file := range []*os.File{f.file, f.offsetFile} {
+		if file == nil {
+			continue
+		}
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Turns a batch of lines into the body of a request, see HTTPConfig.Payload.
+type Payload struct {
+	ContentType string
+	Encode      func(lines []string) ([]byte, error)
+}
+
+var (
+	// Puts every line on a line of its own.
+	NDJSONPayload = Payload{
+		ContentType: "application/x-ndjson",
+		Encode: func(lines []string) ([]byte, error) {
+			return []byte(strings.Join(lines, "\n") + "\n"), nil
+		},
+	}
+
+	// Merges lines written by a LokiEncoder into the body of a Grafana Loki push request, lines with the same
+	// labels end up in the same stream.
+	LokiPayload = Payload{
+		ContentType: "application/json",
+		Encode: func(lines []string) ([]byte, error) {
+			streams := make([]*lokiStream, 0)
+			byLabels := make(map[string]*lokiStream)
+
+			for _, line := range lines {
+				var stream lokiStream
+				if err := json.Unmarshal([]byte(line), &stream); err != nil {
+					return nil, fmt.Errorf("%v was not written by a LokiEncoder: %v", line, err)
+				}
+
+				key := stream.key()
+				if existing, ok := byLabels[key]; ok {
+					existing.Values = append(existing.Values, stream.Values...)
+					continue
+				}
+				byLabels[key] = &stream
+				streams = append(streams, &stream)
+			}
+
+			return json.Marshal(map[string]interface{}{"streams": streams})
+		},
+	}
+)
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Returns the labels of the stream in a form that can be compared.
+func (l *lokiStream) key() string {
+	keys := make([]string, 0, len(l.Stream))
+	for key := range l.Stream {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for index, key := range keys {
+		keys[index] = strconv.Quote(key) + "=" + strconv.Quote(l.Stream[key])
+	}
+	return strings.Join(keys, ",")
+}
+
+/*
+	Creates an Encoder that writes the message as a Grafana Loki stream, meant to be shipped with LokiPayload.
+	The line of the entry is the columns joined by TextEncoder without colors, the same line a ConsoleLogger
+	would write, and it's labeled with the level plus each of the fields named, when the message has them:
+
+		{"stream":{"level":"INFO","service":"api"},"values":[["1598694060000000000","... | INFO | Hello, world"]]}
+
+	The name of the Logger can be used as a label by naming NameColumn.
+*/
+func LokiEncoder(labels ...string) Encoder {
+	return func(context Context, columns []string) string {
+		stream := lokiStream{
+			Stream: map[string]string{"level": context.Level},
+			Values: [][2]string{{
+				strconv.FormatInt(context.Time.UnixNano(), 10),
+				ansi.ReplaceAllString(TextEncoder(context, columns), ""),
+			}},
+		}
+
+		for _, label := range labels {
+			if label == NameColumn && context.Name != "" {
+				stream.Stream[label] = context.Name
+			} else if value := fieldString(context, label); value != "" {
+				stream.Stream[label] = value
+			}
+		}
+
+		encoded, _ := json.Marshal(stream)
+		return string(encoded)
+	}
+}
+
+/*
+	Creates an Encoder that writes the message as an Elasticsearch bulk index action followed by the document
+	JSONEncoder would write, meant to be shipped with NDJSONPayload to the _bulk endpoint:
+
+		{"index":{"_index":"logs"}}
+		{"level":"INFO","message":"Hello, world","time":"2020-08-29T05:41:00.000000000-04:00"}
+
+	Returns an error when the index is empty.
+*/
+func ElasticsearchEncoder(index string) (Encoder, error) {
+	if index == "" {
+		return nil, errors.New("an index is required")
+	}
+
+	action, err := json.Marshal(map[string]interface{}{"index": map[string]string{"_index": index}})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(context Context, columns []string) string {
+		return string(action) + "\n" + JSONEncoder(context, columns)
+	}, nil
+}
+
+// Creates a new LoggerBuilder for making instances of SinkLogger that push to Grafana Loki, see LokiEncoder.
+// The URL of the config should be the push endpoint, e.g. http://localhost:3100/loki/api/v1/push.
+func LokiLoggerBuilder(config HTTPConfig, labels ...string) LoggerBuilder {
+	config.Payload = LokiPayload
+	return SinkLoggerBuilder(NewHTTPSink(config)).SetEncoder(LokiEncoder(labels...))
+}
+
+// Creates a new LoggerBuilder for making instances of SinkLogger that index into Elasticsearch, see
+// ElasticsearchEncoder. The URL of the config should be the bulk endpoint, e.g. http://localhost:9200/_bulk.
+//
+// Returns an error when the index is empty.
+func ElasticsearchLoggerBuilder(config HTTPConfig, index string) (LoggerBuilder, error) {
+	encoder, err := ElasticsearchEncoder(index)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Payload = NDJSONPayload
+	return SinkLoggerBuilder(NewHTTPSink(config)).SetEncoder(encoder), nil
+}
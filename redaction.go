@@ -0,0 +1,278 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// What redacted data is replaced with by the built in Redactor s.
+const RedactedText = "[REDACTED]"
+
+// Replaces sensitive data in a string, see LoggerBuilder.AddRedactor.
+type Redactor interface {
+	Redact(value string) string
+}
+
+// Makes a Redactor out of a function.
+type RedactorFunc func(value string) string
+
+// Implements Redactor.Redact
+func (f RedactorFunc) Redact(value string) string {
+	return f(value)
+}
+
+// Replaces every match of a regular expression.
+type regexRedactor struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+func (r regexRedactor) Redact(value string) string {
+	return r.pattern.ReplaceAllString(value, r.replacement)
+}
+
+// Makes a Redactor that replaces every match of pattern with replacement, which may use $1 style references
+// to the groups of the pattern.
+func RegexRedactor(pattern, replacement string) (Redactor, error) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return regexRedactor{pattern: compiled, replacement: replacement}, nil
+}
+
+var (
+	// Redacts the token of "Bearer <token>", as found in Authorization headers.
+	BearerTokenRedactor Redactor = regexRedactor{
+		pattern:     regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9\-._~+/]+=*`),
+		replacement: "$1 " + RedactedText,
+	}
+
+	// Redacts AWS access key IDs and the value of aws_secret_access_key settings.
+	AWSKeyRedactor Redactor = RedactorFunc(func(value string) string {
+		value = awsAccessKey.ReplaceAllString(value, RedactedText)
+		return awsSecretKey.ReplaceAllString(value, "${1}"+RedactedText)
+	})
+
+	// Redacts email addresses.
+	EmailRedactor Redactor = regexRedactor{
+		pattern:     regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+		replacement: RedactedText,
+	}
+
+	// Redacts credit card numbers, with or without spaces and dashes, that pass the Luhn check.
+	CreditCardRedactor Redactor = RedactorFunc(func(value string) string {
+		return cardNumber.ReplaceAllStringFunc(value, func(match string) string {
+			if luhn(match) {
+				return RedactedText
+			}
+			return match
+		})
+	})
+)
+
+var (
+	awsAccessKey = regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)
+	awsSecretKey = regexp.MustCompile(`(?i)(aws_secret_access_key\s*[:=]\s*["']?)[A-Za-z0-9/+=]{40}`)
+	cardNumber   = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+)
+
+// Checks a number, ignoring spaces and dashes, with the Luhn algorithm.
+func luhn(number string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// Applies every Redactor to the message, extra info and Fields of a Context. Fields are copied
+// before they're redacted, so the map passed to Logger.LogWithExtraInfo is left alone.
+func redactContext(context *Context, redactors []Redactor) {
+	if len(redactors) == 0 {
+		return
+	}
+
+	context.Message = redactString(context.Message, redactors)
+	context.Info = redactValue(context.Info, redactors)
+	if context.Fields != nil {
+		context.Fields = redactValue(context.Fields, redactors).(map[string]interface{})
+	}
+}
+
+func redactString(value string, redactors []Redactor) string {
+	for _, redactor := range redactors {
+		value = redactor.Redact(value)
+	}
+	return value
+}
+
+// How deep redactValue follows pointers and containers, so cyclic values end.
+const maxRedactionDepth = 32
+
+/*
+	Redacts strings, []byte, error s and fmt.Stringer s, and the strings inside maps, slices, arrays, pointers and
+	the exported fields of structs. Values are copied rather than changed, and keep their type where possible:
+	an error or fmt.Stringer on its own is turned into its redacted string, while a field of type error stays an
+	error with the redacted message. Unexported struct fields are copied as they are.
+*/
+func redactValue(value interface{}, redactors []Redactor) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return redactString(v, redactors)
+	case []byte:
+		return []byte(redactString(string(v), redactors))
+	}
+	// Checked before the kind, formatting a redacted copy would still call String on it.
+	if text, ok := redactFormatted(value, redactors); ok {
+		return text
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Ptr, reflect.String:
+		return redactReflected(reflected, redactors, 0).Interface()
+	}
+	return value
+}
+
+// Returns the redacted text of an error or fmt.Stringer, which only shows what it holds once it's formatted.
+// time.Time is left alone, there's nothing in it to redact.
+func redactFormatted(value interface{}, redactors []Redactor) (string, bool) {
+	if reflected := reflect.ValueOf(value); reflected.Kind() == reflect.Ptr && reflected.IsNil() {
+		return "", false
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return "", false
+	case error:
+		return redactString(v.Error(), redactors), true
+	case fmt.Stringer:
+		return redactString(v.String(), redactors), true
+	}
+	return "", false
+}
+
+// What a redacted fmt.Stringer is replaced with where only a fmt.Stringer fits.
+type redactedStringer string
+
+func (r redactedStringer) String() string {
+	return string(r)
+}
+
+func redactReflected(value reflect.Value, redactors []Redactor, depth int) reflect.Value {
+	if depth > maxRedactionDepth {
+		return value
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		redacted := reflect.New(value.Type()).Elem()
+		redacted.SetString(redactString(value.String(), redactors))
+		return redacted
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		redacted := reflect.New(value.Type()).Elem()
+		if text, ok := redactFormatted(value.Interface(), redactors); ok {
+			// Stays an error or a fmt.Stringer where only one fits, e.g. a field of type error.
+			for _, replacement := range []interface{}{text, errors.New(text), redactedStringer(text)} {
+				if reflect.TypeOf(replacement).AssignableTo(value.Type()) {
+					redacted.Set(reflect.ValueOf(replacement))
+					return redacted
+				}
+			}
+		}
+
+		replacement := redactReflected(value.Elem(), redactors, depth+1)
+		if replacement.Type().AssignableTo(value.Type()) {
+			redacted.Set(replacement)
+		} else {
+			redacted.Set(value)
+		}
+		return redacted
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		redacted := reflect.New(value.Type().Elem())
+		redacted.Elem().Set(redactReflected(value.Elem(), redactors, depth+1))
+		return redacted
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		redacted := reflect.MakeMap(value.Type())
+		for _, key := range value.MapKeys() {
+			redacted.SetMapIndex(key, redactReflected(value.MapIndex(key), redactors, depth+1))
+		}
+		return redacted
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			redacted := reflect.New(value.Type()).Elem()
+			redacted.SetBytes([]byte(redactString(string(value.Bytes()), redactors)))
+			return redacted
+		}
+		redacted := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for index := 0; index < value.Len(); index++ {
+			redacted.Index(index).Set(redactReflected(value.Index(index), redactors, depth+1))
+		}
+		return redacted
+	case reflect.Array:
+		redacted := reflect.New(value.Type()).Elem()
+		for index := 0; index < value.Len(); index++ {
+			redacted.Index(index).Set(redactReflected(value.Index(index), redactors, depth+1))
+		}
+		return redacted
+	case reflect.Struct:
+		redacted := reflect.New(value.Type()).Elem()
+		redacted.Set(value)
+		for index := 0; index < value.NumField(); index++ {
+			if field := redacted.Field(index); field.CanSet() {
+				field.Set(redactReflected(value.Field(index), redactors, depth+1))
+			}
+		}
+		return redacted
+	}
+	return value
+}
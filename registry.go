@@ -0,0 +1,311 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+	Hands out Logger s by dotted name, e.g. "api.auth". Every Logger starts from the root builder and
+	inherits the configuration of its parents, so configuring "api" affects "api" and "api.auth", but
+	not "apistats":
+
+		registry, _ := NewRegistry(root)
+		registry.Configure("api", func(builder LoggerBuilder) { builder.SetMinLevel("WARNING") })
+		logger := registry.Get("api.auth")
+
+	The name of each Logger is available to Column s through Context.Name.
+*/
+type Registry struct {
+	root       genericLoggerBuilder
+	sinks      map[string]genericLoggerBuilder
+	configures map[string][]func(builder LoggerBuilder)
+	loggers    map[string]ReconfigurableLogger
+	mutex      sync.Mutex
+	// Counts the configurations added, so work done without holding mutex can tell it's out of date.
+	generation int
+	// Serializes reconfiguring the Logger s handed out, so they end up with the latest configuration.
+	applyMutex sync.Mutex
+}
+
+// Used by GetLogger and ConfigureLoggers. Its root is a ConsoleLoggerBuilder with SetDefaults applied.
+var DefaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	registry, _ := NewRegistry(SetDefaults(ConsoleLoggerBuilder(), nil, nil, nil))
+	return registry
+}
+
+// Makes a new Registry where every Logger starts from a copy of root. The root must be a
+// LoggerBuilder from this package, e.g. ConsoleLoggerBuilder.
+func NewRegistry(root LoggerBuilder) (*Registry, error) {
+	generic, ok := root.Clone().(genericLoggerBuilder)
+	if !ok {
+		return nil, errors.New("the root builder must come from this package")
+	}
+
+	return &Registry{
+		root:       generic,
+		sinks:      make(map[string]genericLoggerBuilder),
+		configures: make(map[string][]func(builder LoggerBuilder)),
+		loggers:    make(map[string]ReconfigurableLogger),
+	}, nil
+}
+
+// Returns the Logger with the name passed, building it the first time it's asked for.
+func (r *Registry) Get(name string) Logger {
+	for {
+		r.mutex.Lock()
+		if logger, ok := r.loggers[name]; ok {
+			r.mutex.Unlock()
+			return logger
+		}
+
+		sink := r.root
+		for _, prefix := range namePrefixes(name) {
+			if builder, ok := r.sinks[prefix]; ok {
+				sink = builder
+			}
+		}
+		builder := sink.Clone().(genericLoggerBuilder)
+		root, configures := r.snapshot(name, "", nil)
+		generation := r.generation
+		r.mutex.Unlock()
+
+		// The configurations are user code, they're run without holding the lock so they can use the Registry.
+		builder.setGeneric(configuration(name, root, configures))
+		logger := builder.Build().(ReconfigurableLogger)
+
+		r.mutex.Lock()
+		if existing, ok := r.loggers[name]; ok {
+			r.mutex.Unlock()
+			return existing
+		}
+		if generation == r.generation {
+			r.loggers[name] = logger
+			r.mutex.Unlock()
+			return logger
+		}
+		// Configure added a configuration in the meantime, which this Logger might be missing.
+		r.mutex.Unlock()
+	}
+}
+
+/*
+	Adds a configuration for the prefix passed and its children. Configurations are applied from the root down,
+	so the configuration of "api.auth" wins over the one of "api". An empty prefix configures every Logger.
+
+	Logger s that were already handed out are reconfigured straight away: configure is applied on top of their
+	current configuration, followed again by the configurations of prefixes more specific than this one. Changes
+	made to them since, e.g. through ReconfigurableLogger.Reconfigure, a LevelHandler or a ConfigWatcher, are kept
+	where configure doesn't touch them.
+
+	configure is run on copies and may be run more than once, it shouldn't do anything but change the builder.
+
+	Returns an error if the configuration doesn't validate for the prefix or one of the Logger s under it,
+	in which case the configuration isn't added and nothing changes.
+*/
+func (r *Registry) Configure(prefix string, configure func(builder LoggerBuilder)) error {
+	for {
+		r.mutex.Lock()
+		loggers := make(map[string]ReconfigurableLogger)
+		for name, logger := range r.loggers {
+			if hasNamePrefix(name, prefix) {
+				loggers[name] = logger
+			}
+		}
+		// The configurations applied after configure for every Logger handed out.
+		after := make(map[string][]func(builder LoggerBuilder), len(loggers))
+		for name := range loggers {
+			after[name] = r.deeper(name, prefix)
+		}
+		root, configures := r.snapshot(prefix, prefix, configure)
+		generation := r.generation
+		r.mutex.Unlock()
+
+		problems := make([]string, 0)
+		if _, ok := loggers[prefix]; !ok {
+			if err := configuration(prefix, root, configures).Validate(); err != nil {
+				problems = append(problems, fmt.Sprintf("%v: %v", prefix, err))
+			}
+		}
+		for name, logger := range loggers {
+			configured, ok := logger.(configuredLogger)
+			if !ok {
+				continue
+			}
+			config := configured.configuration()
+			reconfigure(name, configure, after[name])(config)
+			if err := config.Validate(); err != nil {
+				problems = append(problems, fmt.Sprintf("%v: %v", name, err))
+			}
+		}
+		if len(problems) != 0 {
+			sort.Strings(problems)
+			return errors.New(strings.Join(problems, "; "))
+		}
+
+		r.applyMutex.Lock()
+		r.mutex.Lock()
+		if generation != r.generation || r.countUnder(prefix) != len(loggers) {
+			// Another configuration or Logger was added in the meantime, start over so it isn't lost.
+			r.mutex.Unlock()
+			r.applyMutex.Unlock()
+			continue
+		}
+		r.configures[prefix] = append(r.configures[prefix], configure)
+		r.generation++
+		r.mutex.Unlock()
+
+		for name, logger := range loggers {
+			if err := logger.Reconfigure(reconfigure(name, configure, after[name])); err != nil {
+				problems = append(problems, fmt.Sprintf("%v: %v", name, err))
+			}
+		}
+		r.applyMutex.Unlock()
+
+		if len(problems) != 0 {
+			sort.Strings(problems)
+			return errors.New(strings.Join(problems, "; "))
+		}
+		return nil
+	}
+}
+
+/*
+	Sets where Logger s under the prefix passed log to, for example:
+
+		registry.SetSink("audit", ConsoleLoggerBuilderWithWriter(file))
+
+	Only the sink of the builder is used, the rest of the configuration is still inherited.
+	Logger s that were already handed out keep logging where they did.
+*/
+func (r *Registry) SetSink(prefix string, sink LoggerBuilder) error {
+	generic, ok := sink.Clone().(genericLoggerBuilder)
+	if !ok {
+		return errors.New("the sink builder must come from this package")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.sinks[prefix] = generic
+	return nil
+}
+
+// Returns the names of every Logger handed out so far.
+func (r *Registry) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	names := make([]string, 0, len(r.loggers))
+	for name := range r.loggers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Copies the root and the configurations that apply to the name passed, from the root down, with extra added
+// after the ones of prefix when it's not nil. Must be called with the lock held.
+func (r *Registry) snapshot(name, prefix string, extra func(builder LoggerBuilder)) (*GenericLoggerBuilder, []func(builder LoggerBuilder)) {
+	configures := make([]func(builder LoggerBuilder), 0)
+	for _, current := range namePrefixes(name) {
+		configures = append(configures, r.configures[current]...)
+		if extra != nil && current == prefix {
+			configures = append(configures, extra)
+		}
+	}
+	return r.root.generic().clone(), configures
+}
+
+// Returns the configurations of the prefixes of name more specific than prefix, from the root down.
+// Must be called with the lock held.
+func (r *Registry) deeper(name, prefix string) []func(builder LoggerBuilder) {
+	configures := make([]func(builder LoggerBuilder), 0)
+	for _, current := range namePrefixes(name) {
+		if len(current) > len(prefix) && hasNamePrefix(current, prefix) {
+			configures = append(configures, r.configures[current]...)
+		}
+	}
+	return configures
+}
+
+// Counts the Logger s handed out under the prefix passed. Must be called with the lock held.
+func (r *Registry) countUnder(prefix string) int {
+	count := 0
+	for name := range r.loggers {
+		if hasNamePrefix(name, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// Applies configure and then the configurations after it to the current configuration of a Logger.
+func reconfigure(name string, configure func(builder LoggerBuilder), after []func(builder LoggerBuilder)) func(builder LoggerBuilder) {
+	return func(builder LoggerBuilder) {
+		configure(builder)
+		for _, configure := range after {
+			configure(builder)
+		}
+		builder.(genericLoggerBuilder).generic().Name = name
+	}
+}
+
+// Applies the configurations to the root, in order.
+func configuration(name string, root *GenericLoggerBuilder, configures []func(builder LoggerBuilder)) *GenericLoggerBuilder {
+	for _, configure := range configures {
+		configure(root)
+	}
+	root.Name = name
+	return root
+}
+
+// Returns "", "api" and "api.auth" for "api.auth".
+func namePrefixes(name string) []string {
+	prefixes := []string{""}
+	if name == "" {
+		return prefixes
+	}
+
+	parts := strings.Split(name, ".")
+	for index := range parts {
+		prefixes = append(prefixes, strings.Join(parts[:index+1], "."))
+	}
+	return prefixes
+}
+
+func hasNamePrefix(name, prefix string) bool {
+	return prefix == "" || name == prefix || strings.HasPrefix(name, prefix+".")
+}
+
+// Returns the Logger with the name passed from DefaultRegistry.
+func GetLogger(name string) Logger {
+	return DefaultRegistry.Get(name)
+}
+
+// Configures the prefix passed on DefaultRegistry, see Registry.Configure.
+func ConfigureLoggers(prefix string, configure func(builder LoggerBuilder)) error {
+	return DefaultRegistry.Configure(prefix, configure)
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// A message a RingBufferLogger held on to.
+type Entry struct {
+	Level   string
+	Time    time.Time
+	Name    string
+	Message string
+	Fields  map[string]interface{}
+	// The line the Logger rendered for the message.
+	Line string
+}
+
+// Picks entries out of a RingBufferLogger, every field that's set has to match.
+type EntryFilter struct {
+	Levels []string
+	// Entries logged before Since or after Until are left out.
+	Since time.Time
+	Until time.Time
+	// Matched against the Message of the entry.
+	Pattern *regexp.Regexp
+}
+
+// Returns whether the entry passes the filter.
+func (f EntryFilter) matches(entry Entry) bool {
+	if len(f.Levels) > 0 && findStrings(f.Levels, entry.Level) == -1 {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && entry.Time.After(f.Until) {
+		return false
+	}
+	return f.Pattern == nil || f.Pattern.MatchString(entry.Message)
+}
+
+/*
+	A Logger that holds on to the last entries logged in memory instead of writing them anywhere,
+	see RingBufferLoggerBuilder. Once it's full the oldest entry makes room for the next one.
+
+	With DumpOnError it can act as a backtrace: log everything to it, including levels too noisy to write out,
+	and the entries leading up to an error are written to another Logger once the error happens.
+*/
+type RingBufferLogger struct {
+	SinkLogger
+	ring *ringSink
+}
+
+// Creates a new LoggerBuilder for making instances of RingBufferLogger that hold the last size entries.
+// Every Logger built, including those from clones of the builder, shares the entries.
+func RingBufferLoggerBuilder(size int) LoggerBuilder {
+	if size < 1 {
+		size = 1
+	}
+
+	ring := &ringSink{entries: make([]Entry, size)}
+	return &sinkLoggerBuilder{
+		builder: NewGenericLoggerBuilder(),
+		sink:    ring,
+		wrap: func(logger SinkLogger) Logger {
+			return &RingBufferLogger{SinkLogger: logger, ring: ring}
+		},
+	}
+}
+
+// Returns the entries held right now, oldest first.
+func (r *RingBufferLogger) Snapshot() []Entry {
+	return r.Filter(EntryFilter{})
+}
+
+// Returns the entries held right now that pass the filter, oldest first.
+func (r *RingBufferLogger) Filter(filter EntryFilter) []Entry {
+	r.ring.mutex.Lock()
+	defer r.ring.mutex.Unlock()
+
+	entries := make([]Entry, 0, r.ring.count)
+	for _, entry := range r.ring.ordered() {
+		if filter.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Forgets every entry held.
+func (r *RingBufferLogger) Clear() {
+	r.ring.mutex.Lock()
+	defer r.ring.mutex.Unlock()
+	r.ring.clear()
+}
+
+/*
+	Logs every entry held to logger, oldest first, with the Fields of the entry as extra info.
+	Logger s of this package log the entries with the time they were logged at originally.
+
+	Returns an error when logger writes to the entries of this RingBufferLogger, or for the first entry logger
+	didn't log, the rest are logged regardless.
+*/
+func (r *RingBufferLogger) DumpTo(logger Logger) error {
+	if r.ring.sharedWith(logger) {
+		return errors.New("can't dump the entries into the logger holding them")
+	}
+	return dumpEntries(r.Snapshot(), logger)
+}
+
+/*
+	Makes the RingBufferLogger dump every entry held to logger once an entry at one of the levels is logged,
+	"ERROR" when none are passed, and forget them afterwards. A nil logger stops dumping.
+
+	Returns an error when logger writes to the entries of this RingBufferLogger, like the RingBufferLogger itself
+	or another Logger built from the same builder.
+*/
+func (r *RingBufferLogger) DumpOnError(logger Logger, levels ...string) error {
+	if r.ring.sharedWith(logger) {
+		return errors.New("can't dump the entries into the logger holding them")
+	}
+	if len(levels) == 0 {
+		levels = []string{"ERROR"}
+	}
+
+	r.ring.mutex.Lock()
+	defer r.ring.mutex.Unlock()
+	r.ring.dump = logger
+	r.ring.dumpLevels = levels
+	return nil
+}
+
+// The methods of SinkLogger dumping entries relies on.
+type replayLogger interface {
+	logAt(at time.Time, level, message string, info interface{}) (int, error)
+	writesTo(sink Sink) bool
+}
+
+func dumpEntries(entries []Entry, logger Logger) error {
+	replay, replayable := logger.(replayLogger)
+
+	var err error
+	for _, entry := range entries {
+		var info interface{}
+		if entry.Fields != nil {
+			info = entry.Fields
+		}
+
+		var code int
+		var logErr error
+		if replayable {
+			code, logErr = replay.logAt(entry.Time, entry.Level, entry.Message, info)
+		} else {
+			code, logErr = logger.LogWithExtraInfo(entry.Level, entry.Message, info)
+		}
+		if code != Success && code != BelowMinLevel && code != Sampled && err == nil {
+			if logErr == nil {
+				logErr = errors.New(fmt.Sprintf("status code %v", code))
+			}
+			err = fmt.Errorf("could not dump %q: %v", entry.Message, logErr)
+		}
+	}
+	return err
+}
+
+// The Sink of a RingBufferLogger, entries is used as a circular buffer with next being the oldest once it's full.
+type ringSink struct {
+	mutex      sync.Mutex
+	entries    []Entry
+	next       int
+	count      int
+	dump       Logger
+	dumpLevels []string
+}
+
+func (r *ringSink) Write(context Context, line string) error {
+	r.mutex.Lock()
+	r.entries[r.next] = Entry{
+		Level:   context.Level,
+		Time:    context.Time,
+		Name:    context.Name,
+		Message: context.Message,
+		Fields:  context.Fields,
+		Line:    line,
+	}
+	r.next = (r.next + 1) % len(r.entries)
+	if r.count < len(r.entries) {
+		r.count++
+	}
+
+	if r.dump == nil || findStrings(r.dumpLevels, context.Level) == -1 {
+		r.mutex.Unlock()
+		return nil
+	}
+
+	entries, logger := r.ordered(), r.dump
+	r.clear()
+	r.mutex.Unlock()
+	return dumpEntries(entries, logger)
+}
+
+// Returns whether logger writes to this ringSink.
+func (r *ringSink) sharedWith(logger Logger) bool {
+	replay, ok := logger.(replayLogger)
+	return ok && replay.writesTo(r)
+}
+
+func (r *ringSink) Close() error {
+	return nil
+}
+
+// Returns the entries held, oldest first.
+func (r *ringSink) ordered() []Entry {
+	entries := make([]Entry, 0, r.count)
+	start := (r.next - r.count + len(r.entries)) % len(r.entries)
+	for index := 0; index < r.count; index++ {
+		entries = append(entries, r.entries[(start+index)%len(r.entries)])
+	}
+	return entries
+}
+
+func (r *ringSink) clear() {
+	for index := range r.entries {
+		r.entries[index] = Entry{}
+	}
+	r.next = 0
+	r.count = 0
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Logs the first First messages with the same level and message in every Interval, and after that
+// every Thereafter'th one. A Thereafter of 0 drops everything after the first First messages.
+type Sampling struct {
+	First      int
+	Thereafter int
+	Interval   time.Duration
+}
+
+// Allows PerSecond messages of a level per second on average, with bursts of up to Burst messages.
+type RateLimit struct {
+	PerSecond float64
+	Burst     int
+}
+
+// How often a summary of dropped messages is logged when only rate limits are set.
+const rateLimitSummaryInterval = time.Second
+
+// A token bucket for a single level.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Applies the Sampling and RateLimit s of a loggerState and counts what they drop.
+type sampler struct {
+	sampling   *Sampling
+	limits     map[string]RateLimit
+	mutex      sync.Mutex
+	window     time.Time
+	counts     map[string]int
+	buckets    map[string]*tokenBucket
+	suppressed map[string]int
+	timer      *time.Timer
+	// Called with the number of messages of a level that were dropped since the last summary.
+	summarize func(level string, suppressed int)
+}
+
+func newSampler(sampling *Sampling, limits map[string]RateLimit) *sampler {
+	if sampling == nil && len(limits) == 0 {
+		return nil
+	}
+
+	return &sampler{
+		sampling:   sampling,
+		limits:     limits,
+		counts:     make(map[string]int),
+		buckets:    make(map[string]*tokenBucket),
+		suppressed: make(map[string]int),
+	}
+}
+
+// Returns whether a message logged at now should be logged. A nil sampler allows everything.
+func (s *sampler) allow(level, message string, now time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.sampled(level, message, now) || !s.limited(level, now) {
+		s.suppressed[level]++
+		if s.timer == nil && s.summarize != nil {
+			s.timer = time.AfterFunc(s.summaryInterval(), s.flush)
+		}
+		return false
+	}
+	return true
+}
+
+func (s *sampler) sampled(level, message string, now time.Time) bool {
+	if s.sampling == nil {
+		return true
+	}
+
+	if now.Sub(s.window) >= s.sampling.Interval {
+		s.window = now
+		s.counts = make(map[string]int)
+	}
+
+	key := level + "\x00" + message
+	s.counts[key]++
+	count := s.counts[key]
+
+	if count <= s.sampling.First {
+		return true
+	}
+	return s.sampling.Thereafter > 0 && (count-s.sampling.First)%s.sampling.Thereafter == 0
+}
+
+func (s *sampler) limited(level string, now time.Time) bool {
+	limit, ok := s.limits[level]
+	if !ok {
+		return true
+	}
+
+	bucket, ok := s.buckets[level]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.Burst), last: now}
+		s.buckets[level] = bucket
+	}
+
+	bucket.tokens += now.Sub(bucket.last).Seconds() * limit.PerSecond
+	if bucket.tokens > float64(limit.Burst) {
+		bucket.tokens = float64(limit.Burst)
+	}
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+func (s *sampler) summaryInterval() time.Duration {
+	if s.sampling != nil && s.sampling.Interval > 0 {
+		return s.sampling.Interval
+	}
+	return rateLimitSummaryInterval
+}
+
+// Logs a summary line for every level that had messages dropped.
+func (s *sampler) flush() {
+	s.mutex.Lock()
+	suppressed := s.suppressed
+	s.suppressed = make(map[string]int)
+	s.timer = nil
+	s.mutex.Unlock()
+
+	levels := make([]string, 0, len(suppressed))
+	for level := range suppressed {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+
+	for _, level := range levels {
+		s.summarize(level, suppressed[level])
+	}
+}
@@ -0,0 +1,373 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Where a SinkLogger writes its lines, e.g. syslog or a network connection.
+// Writes are serialized by the Logger, so a Sink doesn't need to be safe for concurrent writes.
+type Sink interface {
+	// Writes the line rendered for the Context.
+	Write(context Context, line string) error
+	// Releases whatever the Sink holds on to, the Sink isn't written to afterwards.
+	Close() error
+}
+
+// A Logger implementation that writes to a Sink, see SinkLoggerBuilder.
+type SinkLogger struct {
+	core      *loggerCore
+	sink      Sink
+	component string
+}
+
+// Implements Logger.GetLevels
+func (s SinkLogger) GetLevels() map[string]func() string {
+	return s.core.load().levels
+}
+
+// Implements Logger.GetPaddings
+func (s SinkLogger) GetPaddings() []Padding {
+	return s.core.load().paddings
+}
+
+// Implements Logger.GetColumns
+func (s SinkLogger) GetColumns() []Column {
+	return s.core.load().columns
+}
+
+// Implements ReconfigurableLogger.GetSeverities
+func (s SinkLogger) GetSeverities() map[string]int {
+	return s.core.load().severities
+}
+
+// Implements ReconfigurableLogger.GetName
+func (s SinkLogger) GetName() string {
+	return joinName(s.core.load().name, s.component)
+}
+
+// Implements ReconfigurableLogger.Named
+func (s SinkLogger) Named(component string) ReconfigurableLogger {
+	s.component = joinName(s.component, component)
+	return s
+}
+
+// Implements ReconfigurableLogger.GetMinLevel
+func (s SinkLogger) GetMinLevel() string {
+	return s.core.load().minLevel
+}
+
+// Implements ReconfigurableLogger.IsLevelEnabled
+func (s SinkLogger) IsLevelEnabled(level string) bool {
+	state := s.core.load()
+	_, ok := state.levels[level]
+	return ok && state.enabled(level)
+}
+
+// Implements ReconfigurableLogger.Reconfigure
+func (s SinkLogger) Reconfigure(configure func(builder LoggerBuilder)) error {
+	return s.core.reconfigure(configure)
+}
+
+// Implements Logger.Log, see Logger.LogWithExtraInfo.
+func (s SinkLogger) Log(level, message string) (int, error) {
+	return s.LogWithExtraInfo(level, message, nil)
+}
+
+/*
+	Implements Logger.LogWithExtraInfo. The info is available to Column s and the Encoder through the Context.
+
+	Returns
+		- Success when there was no problems
+		- InvalidLevel when the level provided isn't in this Logger
+		- NoColumnsSet when there are no Columns set for this Logger
+		- BelowMinLevel when the level provided is below the minimum level of this Logger or disabled
+		- Sampled when the message was dropped by sampling or rate limiting
+		- Aborted when a Hook aborted the write
+		- WriteFailed when the line couldn't be written
+*/
+func (s SinkLogger) LogWithExtraInfo(level, message string, info interface{}) (int, error) {
+	return s.core.log(nil, s, s.component, level, message, info)
+}
+
+// Implements ContextLogger.LogCtx
+func (s SinkLogger) LogCtx(ctx context.Context, level, message string) (int, error) {
+	return s.LogCtxWithExtraInfo(ctx, level, message, nil)
+}
+
+// Implements ContextLogger.LogCtxWithExtraInfo, see SinkLogger.LogWithExtraInfo for what's returned.
+func (s SinkLogger) LogCtxWithExtraInfo(ctx context.Context, level, message string, info interface{}) (int, error) {
+	return s.core.log(ctx, s, s.component, level, message, info)
+}
+
+// Logs like LogWithExtraInfo as if the message was logged at the time passed, for replaying entries.
+func (s SinkLogger) logAt(at time.Time, level, message string, info interface{}) (int, error) {
+	return s.core.logAt(nil, s, s.component, level, message, info, at)
+}
+
+// Returns whether this Logger writes to the sink passed.
+func (s SinkLogger) writesTo(sink Sink) bool {
+	return s.sink == sink
+}
+
+// Returns a copy of the current configuration of this Logger.
+func (s SinkLogger) configuration() *GenericLoggerBuilder {
+	return s.core.load().builder()
+}
+
+// Closes the Sink of this Logger.
+func (s SinkLogger) Close() error {
+	if s.sink == nil {
+		return nil
+	}
+	return s.sink.Close()
+}
+
+// Creates a new LoggerBuilder for making instances of SinkLogger that write to the sink passed.
+// Every Logger built, including those from clones of the builder, shares the sink.
+func SinkLoggerBuilder(sink Sink) LoggerBuilder {
+	return &sinkLoggerBuilder{
+		builder: NewGenericLoggerBuilder(),
+		sink:    sink,
+	}
+}
+
+/*
+	Creates a new LoggerBuilder for making instances of SinkLogger that write to the sink passed, starting from a copy
+	of the configuration of builder. This is how the output of any Logger can be captured, e.g. in tests.
+
+	Returns an error when builder wasn't made by this package, since its configuration can't be copied.
+*/
+func SinkLoggerBuilderFrom(builder LoggerBuilder, sink Sink) (LoggerBuilder, error) {
+	b, ok := builder.(genericLoggerBuilder)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("the configuration of %T can't be copied", builder))
+	}
+
+	return &sinkLoggerBuilder{
+		builder: b.generic().clone(),
+		sink:    sink,
+	}, nil
+}
+
+type sinkLoggerBuilder struct {
+	builder *GenericLoggerBuilder
+	sink    Sink
+	// Turns the SinkLogger that was built into the Logger returned from Build, nil returns it as it is.
+	wrap func(logger SinkLogger) Logger
+}
+
+func (b *sinkLoggerBuilder) AddLevel(name string, display func() string) LoggerBuilder {
+	b.builder.AddLevel(name, display)
+	return b
+}
+
+func (b *sinkLoggerBuilder) SetSeverity(name string, severity int) LoggerBuilder {
+	b.builder.SetSeverity(name, severity)
+	return b
+}
+
+func (b *sinkLoggerBuilder) SetMinLevel(name string) LoggerBuilder {
+	b.builder.SetMinLevel(name)
+	return b
+}
+
+func (b *sinkLoggerBuilder) DisableLevel(name string) LoggerBuilder {
+	b.builder.DisableLevel(name)
+	return b
+}
+
+func (b *sinkLoggerBuilder) EnableLevel(name string) LoggerBuilder {
+	b.builder.EnableLevel(name)
+	return b
+}
+
+func (b *sinkLoggerBuilder) RemoveLevel(name string) LoggerBuilder {
+	b.builder.RemoveLevel(name)
+	return b
+}
+
+func (b *sinkLoggerBuilder) HasLevel(name string) bool {
+	return b.builder.HasLevel(name)
+}
+
+func (b *sinkLoggerBuilder) ListLevels() []string {
+	return b.builder.ListLevels()
+}
+
+func (b *sinkLoggerBuilder) AddPadding(padding Padding) LoggerBuilder {
+	b.builder.AddPadding(padding)
+	return b
+}
+
+func (b *sinkLoggerBuilder) RemovePadding(padding Padding) LoggerBuilder {
+	b.builder.RemovePadding(padding)
+	return b
+}
+
+func (b *sinkLoggerBuilder) AddColumn(column Column) LoggerBuilder {
+	b.builder.AddColumn(column)
+	return b
+}
+
+func (b *sinkLoggerBuilder) AddColumnByIndex(index uint, column Column) (LoggerBuilder, error) {
+	_, err := b.builder.AddColumnByIndex(index, column)
+	return b, err
+}
+
+func (b *sinkLoggerBuilder) AddNamedColumn(name string, column Column) LoggerBuilder {
+	b.builder.AddNamedColumn(name, column)
+	return b
+}
+
+func (b *sinkLoggerBuilder) AddNamedColumnByIndex(index uint, name string, column Column) (LoggerBuilder, error) {
+	_, err := b.builder.AddNamedColumnByIndex(index, name, column)
+	return b, err
+}
+
+func (b *sinkLoggerBuilder) AddPaddedColumn(index uint, name string, column Column, paddings ...Padding) (LoggerBuilder, error) {
+	_, err := b.builder.AddPaddedColumn(index, name, column, paddings...)
+	return b, err
+}
+
+func (b *sinkLoggerBuilder) RemoveColumn(name string) (LoggerBuilder, error) {
+	_, err := b.builder.RemoveColumn(name)
+	return b, err
+}
+
+func (b *sinkLoggerBuilder) ReplaceColumn(name string, column Column) (LoggerBuilder, error) {
+	_, err := b.builder.ReplaceColumn(name, column)
+	return b, err
+}
+
+func (b *sinkLoggerBuilder) MoveColumn(name string, index uint) (LoggerBuilder, error) {
+	_, err := b.builder.MoveColumn(name, index)
+	return b, err
+}
+
+func (b *sinkLoggerBuilder) InsertBefore(name, newName string, column Column) (LoggerBuilder, error) {
+	_, err := b.builder.InsertBefore(name, newName, column)
+	return b, err
+}
+
+func (b *sinkLoggerBuilder) InsertAfter(name, newName string, column Column) (LoggerBuilder, error) {
+	_, err := b.builder.InsertAfter(name, newName, column)
+	return b, err
+}
+
+func (b *sinkLoggerBuilder) SetSampling(first, thereafter int, interval time.Duration) LoggerBuilder {
+	b.builder.SetSampling(first, thereafter, interval)
+	return b
+}
+
+func (b *sinkLoggerBuilder) SetRateLimit(level string, perSecond float64, burst int) LoggerBuilder {
+	b.builder.SetRateLimit(level, perSecond, burst)
+	return b
+}
+
+func (b *sinkLoggerBuilder) AddHook(hook Hook, levels ...string) LoggerBuilder {
+	b.builder.AddHook(hook, levels...)
+	return b
+}
+
+func (b *sinkLoggerBuilder) AddRedactor(redactors ...Redactor) LoggerBuilder {
+	b.builder.AddRedactor(redactors...)
+	return b
+}
+
+func (b *sinkLoggerBuilder) AddExtractor(extractors ...Extractor) LoggerBuilder {
+	b.builder.AddExtractor(extractors...)
+	return b
+}
+
+func (b *sinkLoggerBuilder) SetName(name string) LoggerBuilder {
+	b.builder.SetName(name)
+	return b
+}
+
+func (b *sinkLoggerBuilder) SetEncoder(encoder Encoder) LoggerBuilder {
+	b.builder.SetEncoder(encoder)
+	return b
+}
+
+func (b *sinkLoggerBuilder) SetClock(clock Clock) LoggerBuilder {
+	b.builder.SetClock(clock)
+	return b
+}
+
+func (b *sinkLoggerBuilder) SetLocation(location *time.Location) LoggerBuilder {
+	b.builder.SetLocation(location)
+	return b
+}
+
+func (b *sinkLoggerBuilder) Clone() LoggerBuilder {
+	return &sinkLoggerBuilder{
+		builder: b.builder.clone(),
+		sink:    b.sink,
+		wrap:    b.wrap,
+	}
+}
+
+func (b *sinkLoggerBuilder) generic() *GenericLoggerBuilder {
+	return b.builder
+}
+
+func (b *sinkLoggerBuilder) setGeneric(builder *GenericLoggerBuilder) {
+	b.builder = builder
+}
+
+// Implements LoggerBuilder.BuildE, which also reports a nil sink.
+func (b *sinkLoggerBuilder) BuildE() (Logger, error) {
+	err := b.builder.Validate()
+	if b.sink == nil {
+		problems := []string{"a sink is required"}
+		if buildErr, ok := err.(*BuildError); ok {
+			problems = append(buildErr.Problems, problems...)
+		}
+		return nil, &BuildError{Problems: problems}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b.Build(), nil
+}
+
+func (b *sinkLoggerBuilder) Build() Logger {
+	logger := SinkLogger{
+		core: newLoggerCore(b.builder),
+		sink: b.sink,
+	}
+	logger.core.owner = logger
+	if b.sink == nil {
+		// BuildE rejects this, a Logger from Build fails every write instead.
+		logger.core.write = func(context Context, line string) error { return errors.New("the logger has no sink") }
+	} else {
+		logger.core.write = b.sink.Write
+	}
+
+	if b.wrap == nil {
+		return &logger
+	}
+	return b.wrap(logger)
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// An immutable snapshot of the configuration a built Logger logs with.
+type loggerState struct {
+	config      *GenericLoggerBuilder
+	name        string
+	levels      map[string]func() string
+	severities  map[string]int
+	minLevel    string
+	disabled    map[string]interface{}
+	paddings    []Padding
+	columns     []Column
+	columnNames []string
+	encoder     Encoder
+	clock       Clock
+	location    *time.Location
+	sampler     *sampler
+	hooks       []LevelHook
+	redactors   []Redactor
+	extractors  []Extractor
+}
+
+// Copies the configuration out of a builder, so later changes to the builder don't leak into the Logger.
+func newLoggerState(b *GenericLoggerBuilder) *loggerState {
+	clone := b.clone()
+	paddings := make([]Padding, 0)
+
+	for key := range clone.Paddings {
+		paddings = append(paddings, key)
+	}
+
+	return &loggerState{
+		config:      clone,
+		name:        clone.Name,
+		levels:      clone.Levels,
+		severities:  clone.Severities,
+		minLevel:    clone.MinLevel,
+		disabled:    clone.DisabledLevels,
+		paddings:    paddings,
+		columns:     clone.Columns,
+		columnNames: clone.ColumnNames,
+		encoder:     clone.Encoder,
+		clock:       clone.Clock,
+		location:    clone.Location,
+		sampler:     newSampler(clone.Sampling, clone.RateLimits),
+		hooks:       clone.Hooks,
+		redactors:   clone.Redactors,
+		extractors:  clone.Extractors,
+	}
+}
+
+// Turns the snapshot back into a builder that can be changed freely.
+func (s *loggerState) builder() *GenericLoggerBuilder {
+	if s.config == nil {
+		return NewGenericLoggerBuilder()
+	}
+	return s.config.clone()
+}
+
+// Returns whether the level passed isn't disabled and is at or above the minimum level.
+// Levels without a severity can't be ranked against the minimum level, so they're always enabled.
+func (s *loggerState) enabled(level string) bool {
+	if _, ok := s.disabled[level]; ok {
+		return false
+	}
+	severity, ok := s.severities[level]
+	if s.minLevel == "" || !ok {
+		return true
+	}
+	return severity >= s.severities[s.minLevel]
+}
+
+// Evaluates every Column against the Context and encodes the result into a line.
+func (s *loggerState) render(context Context) string {
+	columns := make([]string, len(s.columns))
+	for index, column := range s.columns {
+		columns[index] = column(context)
+	}
+
+	encoder := s.encoder
+	if encoder == nil {
+		encoder = TextEncoder
+	}
+	return encoder(context, columns)
+}
+
+// Returns the current time of the Clock of the Logger, in its time zone.
+func (s *loggerState) now() time.Time {
+	return currentTime(s.clock, s.location)
+}
+
+func (s *loggerState) createContext(ctx context.Context, logger Logger, component, level, message string, info interface{}, now time.Time) Context {
+	return Context{
+		Ctx:     ctx,
+		Name:    joinName(s.name, component),
+		Message: message,
+		Time:    now,
+		Level:   level,
+		Logger:  logger,
+		Info:    info,
+		Fields:  collectFields(ctx, s.extractors, info),
+	}
+}
+
+// Holds the current loggerState of a built Logger and swaps it when the Logger is reconfigured.
+// It also runs the steps every Logger of this package shares before handing a line to write.
+type loggerCore struct {
+	state atomic.Value
+	mutex sync.Mutex
+	// The Logger that was built, used for Context.Logger of lines the core logs by itself.
+	owner Logger
+	write func(context Context, line string) error
+	// Serializes calls to write, so Logger s don't need to be safe for concurrent writes themselves.
+	writeMutex sync.Mutex
+}
+
+func newLoggerCore(b *GenericLoggerBuilder) *loggerCore {
+	core := &loggerCore{}
+	core.store(newLoggerState(b))
+	return core
+}
+
+// Returns the current snapshot. A nil core acts like a Logger built from an empty builder.
+func (c *loggerCore) load() *loggerState {
+	if c == nil {
+		return &loggerState{}
+	}
+	return c.state.Load().(*loggerState)
+}
+
+func (c *loggerCore) store(state *loggerState) {
+	if state.sampler != nil {
+		state.sampler.summarize = c.summarize
+	}
+	c.state.Store(state)
+}
+
+// Applies configure to a copy of the current configuration and stores it if it validates.
+// Reconfigurations are serialized so concurrent calls don't lose each others changes.
+func (c *loggerCore) reconfigure(configure func(builder LoggerBuilder)) error {
+	if c == nil {
+		return errors.New("this logger was not built by a LoggerBuilder")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	b := c.load().builder()
+	configure(b)
+	if err := b.Validate(); err != nil {
+		return err
+	}
+
+	c.store(newLoggerState(b))
+	return nil
+}
+
+/*
+	Implements ContextLogger.LogCtxWithExtraInfo for every Logger of this package, ctx may be nil.
+
+	Returns
+		- Success when there was no problems
+		- InvalidLevel when the level provided isn't in this Logger
+		- NoColumnsSet when there are no Columns set for this Logger
+		- BelowMinLevel when the level provided is below the minimum level of this Logger or disabled
+		- Sampled when the message was dropped by sampling or rate limiting
+		- Aborted when a Hook aborted the write
+		- WriteFailed when the line couldn't be written
+*/
+func (c *loggerCore) log(ctx context.Context, logger Logger, component, level, message string, info interface{}) (int, error) {
+	return c.logAt(ctx, logger, component, level, message, info, time.Time{})
+}
+
+// Logs like loggerCore.log as if the message was logged at the time passed, the zero time being now.
+func (c *loggerCore) logAt(ctx context.Context, logger Logger, component, level, message string, info interface{}, at time.Time) (int, error) {
+	state := c.load()
+	if _, ok := state.levels[level]; !ok {
+		return InvalidLevel, errors.New(fmt.Sprintf("%v is not a valid level for this Logger", level))
+	}
+
+	if len(state.columns) == 0 {
+		return NoColumnsSet, errors.New("you must set at least one column")
+	}
+
+	if !state.enabled(level) {
+		return BelowMinLevel, nil
+	}
+
+	// The clock is only read once, so a Clock that moves on every read moves once per message.
+	now := state.now()
+	if !state.sampler.allow(level, message, now) {
+		return Sampled, nil
+	}
+	if !at.IsZero() {
+		now = at.In(now.Location())
+	}
+
+	context := state.createContext(ctx, logger, component, level, message, info, now)
+	redactContext(&context, state.redactors)
+
+	hooks := make([]Hook, 0, len(state.hooks))
+	for _, hook := range state.hooks {
+		if !hook.matches(level) {
+			continue
+		}
+
+		hooks = append(hooks, hook.Hook)
+		if !hook.Hook.Before(&context) {
+			return Aborted, nil
+		}
+	}
+
+	if _, ok := state.levels[context.Level]; !ok {
+		return InvalidLevel, errors.New(fmt.Sprintf("a hook changed the level to %v which is not a valid level for this Logger", context.Level))
+	}
+
+	line := state.render(context)
+	err := c.emit(context, line)
+	for _, hook := range hooks {
+		hook.After(context, line, err)
+	}
+
+	if err != nil {
+		return WriteFailed, err
+	}
+	return Success, nil
+}
+
+// Logs how many messages of a level sampling or rate limiting dropped.
+func (c *loggerCore) summarize(level string, suppressed int) {
+	state := c.load()
+	if _, ok := state.levels[level]; !ok || !state.enabled(level) || len(state.columns) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("suppressed %v similar %v entries", suppressed, level)
+	context := state.createContext(nil, c.owner, "", level, message, nil, state.now())
+	_ = c.emit(context, state.render(context))
+}
+
+func (c *loggerCore) emit(context Context, line string) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	return c.write(context, line)
+}
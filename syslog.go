@@ -0,0 +1,247 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The format of the messages a syslog sink writes.
+type SyslogFormat int
+
+const (
+	// The format of RFC 5424, e.g. "<14>1 2020-08-29T05:41:00Z host app 42 - - Hello, world"
+	RFC5424 SyslogFormat = iota
+	// The BSD format of RFC 3164, e.g. "<14>Aug 29 05:41:00 host app[42]: Hello, world"
+	RFC3164
+)
+
+// Syslog severities, see RFC 5424 section 6.2.1.
+const (
+	SyslogEmergency = iota
+	SyslogAlert
+	SyslogCritical
+	SyslogError
+	SyslogWarning
+	SyslogNotice
+	SyslogInformational
+	SyslogDebug
+)
+
+// The facility the messages are sent with when SyslogConfig.Facility is left at 0, which is kern.
+const SyslogUserFacility = 1
+
+// Where the local syslog daemon usually listens, tried in order when SyslogConfig.Network is empty.
+var syslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// The syslog severities of the levels SetDefaults adds.
+var defaultSyslogSeverities = map[string]int{
+	"DEBUG":   SyslogDebug,
+	"INFO":    SyslogInformational,
+	"WARNING": SyslogWarning,
+	"ERROR":   SyslogError,
+	"FATAL":   SyslogCritical,
+}
+
+// Configures where and how a syslog sink sends its messages.
+type SyslogConfig struct {
+	// "udp", "tcp", "unix" or "unixgram". Empty connects to the local syslog daemon.
+	Network string
+	// The address to dial, e.g. "localhost:514" or "/dev/log".
+	Address string
+	Format  SyslogFormat
+	// The facility code, e.g. 16 for local0. Defaults to SyslogUserFacility.
+	Facility int
+	// Defaults to os.Hostname.
+	Hostname string
+	// Defaults to the name of the executable.
+	AppName string
+	// The syslog severity of every level, on top of the defaults for the levels SetDefaults adds.
+	// Levels that aren't in here are mapped by their severity, see LoggerBuilder.SetSeverity.
+	Severities map[string]int
+}
+
+// Creates a new LoggerBuilder for making instances of SinkLogger that send to a syslog daemon.
+// The connection is made on the first message and made again when writing fails.
+func SyslogLoggerBuilder(config SyslogConfig) LoggerBuilder {
+	return SinkLoggerBuilder(newSyslogSink(config))
+}
+
+type syslogSink struct {
+	config SyslogConfig
+	conn   net.Conn
+	// The network conn was dialed on.
+	network string
+	pid     int
+}
+
+func newSyslogSink(config SyslogConfig) *syslogSink {
+	if config.Facility == 0 {
+		config.Facility = SyslogUserFacility
+	}
+	if config.Hostname == "" {
+		config.Hostname, _ = os.Hostname()
+	}
+	if config.AppName == "" {
+		config.AppName = filepath.Base(os.Args[0])
+	}
+	config.Hostname = syslogHeaderField(config.Hostname, 255)
+	config.AppName = syslogHeaderField(config.AppName, 48)
+	return &syslogSink{config: config, pid: os.Getpid()}
+}
+
+// Replaces everything but printable ASCII, which RFC 5424 requires of HOSTNAME and APP-NAME, and cuts the value
+// off at max characters. An empty value becomes the NILVALUE "-".
+func syslogHeaderField(value string, max int) string {
+	field := []byte(value)
+	for index, char := range field {
+		if char < 33 || char > 126 {
+			field[index] = '_'
+		}
+	}
+	if len(field) > max {
+		field = field[:max]
+	}
+	if len(field) == 0 {
+		return "-"
+	}
+	return string(field)
+}
+
+func (s *syslogSink) Write(context Context, line string) error {
+	line = ansi.ReplaceAllString(line, "")
+
+	if s.conn != nil {
+		if _, err := s.conn.Write(s.format(context, line)); err == nil {
+			return nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+
+	if err := s.connect(); err != nil {
+		return err
+	}
+	if _, err := s.conn.Write(s.format(context, line)); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *syslogSink) connect() error {
+	if s.config.Network != "" {
+		conn, err := net.Dial(s.config.Network, s.config.Address)
+		if err != nil {
+			return err
+		}
+		s.conn, s.network = conn, s.config.Network
+		return nil
+	}
+
+	for _, path := range syslogSockets {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.Dial(network, path); err == nil {
+				s.conn, s.network = conn, network
+				return nil
+			}
+		}
+	}
+	return errors.New("could not connect to the local syslog daemon")
+}
+
+// The layout of RFC 5424 timestamps, which allow at most 6 digits for fractions of a second.
+const rfc5424Timestamp = "2006-01-02T15:04:05.000000Z07:00"
+
+// Returns the message for the line, framed for the connection it's sent over.
+func (s *syslogSink) format(context Context, line string) []byte {
+	priority := s.config.Facility*8 + s.severity(context)
+	var message string
+
+	switch s.config.Format {
+	case RFC3164:
+		message = fmt.Sprintf("<%v>%v %v %v[%v]: %v", priority, context.Time.Format(time.Stamp),
+			s.config.Hostname, s.config.AppName, s.pid, line)
+	default:
+		message = fmt.Sprintf("<%v>1 %v %v %v %v - - %v", priority, context.Time.Format(rfc5424Timestamp),
+			s.config.Hostname, s.config.AppName, s.pid, line)
+	}
+
+	// Stream sockets, tcp and unix, need the message to be framed.
+	if !isPacketNetwork(s.network) {
+		if s.config.Format == RFC5424 {
+			// Octet counting, see RFC 6587 section 3.4.1.
+			return []byte(fmt.Sprintf("%v %v", len(message), message))
+		}
+		return []byte(message + "\n")
+	}
+	return []byte(message)
+}
+
+// Returns the syslog severity of the level of the Context.
+func (s *syslogSink) severity(context Context) int {
+	return syslogSeverity(context, s.config.Severities)
+}
+
+// Returns the syslog severity of the level of the Context, looking in severities before the defaults.
+func syslogSeverity(context Context, severities map[string]int) int {
+	if severity, ok := severities[context.Level]; ok {
+		return severity
+	}
+	if severity, ok := defaultSyslogSeverities[strings.ToUpper(context.Level)]; ok {
+		return severity
+	}
+
+	logger, ok := context.Logger.(ReconfigurableLogger)
+	if !ok {
+		return SyslogInformational
+	}
+	severity, ok := logger.GetSeverities()[context.Level]
+	switch {
+	case !ok:
+		return SyslogInformational
+	case severity >= FatalSeverity:
+		return SyslogCritical
+	case severity >= ErrorSeverity:
+		return SyslogError
+	case severity >= WarningSeverity:
+		return SyslogWarning
+	case severity >= InfoSeverity:
+		return SyslogInformational
+	default:
+		return SyslogDebug
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"context"
+)
+
+const (
+	// The Fields key TraceExtractor stores the trace ID under
+	TraceIDField = "trace_id"
+	// The Fields key TraceExtractor stores the span ID under
+	SpanIDField = "span_id"
+)
+
+/*
+	The part of a span this package needs to correlate messages with traces. There is no dependency on
+	a tracing library, an OpenTelemetry span can be adapted with:
+
+		TraceExtractor(func(ctx context.Context) SpanContext {
+			span := trace.SpanContextFromContext(ctx)
+			if !span.IsValid() {
+				return nil
+			}
+			return NewSpanContext(span.TraceID().String(), span.SpanID().String())
+		})
+*/
+type SpanContext interface {
+	TraceID() string
+	SpanID() string
+}
+
+type spanContext struct {
+	traceID string
+	spanID  string
+}
+
+func (s spanContext) TraceID() string {
+	return s.traceID
+}
+
+func (s spanContext) SpanID() string {
+	return s.spanID
+}
+
+// Makes a SpanContext out of a trace ID and a span ID.
+func NewSpanContext(traceID, spanID string) SpanContext {
+	return spanContext{traceID: traceID, spanID: spanID}
+}
+
+type spanKey struct{}
+
+// Returns a copy of ctx that carries span, for use with SpanExtractor.
+func ContextWithSpan(ctx context.Context, span SpanContext) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// Returns the SpanContext stored by ContextWithSpan, or nil if there is none.
+func SpanFromContext(ctx context.Context) SpanContext {
+	span, _ := ctx.Value(spanKey{}).(SpanContext)
+	return span
+}
+
+// Makes an Extractor that stores the IDs of the span lookup returns under TraceIDField and SpanIDField.
+// Nothing is stored when lookup returns nil or empty IDs.
+func TraceExtractor(lookup func(ctx context.Context) SpanContext) Extractor {
+	return func(ctx context.Context) map[string]interface{} {
+		span := lookup(ctx)
+		if span == nil {
+			return nil
+		}
+
+		fields := make(map[string]interface{}, 2)
+		if id := span.TraceID(); id != "" {
+			fields[TraceIDField] = id
+		}
+		if id := span.SpanID(); id != "" {
+			fields[SpanIDField] = id
+		}
+		return fields
+	}
+}
+
+// An Extractor for spans stored with ContextWithSpan.
+var SpanExtractor = TraceExtractor(SpanFromContext)
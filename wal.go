@@ -0,0 +1,367 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Configures where a disk Buffer keeps its segments and how much space it may use.
+type DiskBufferConfig struct {
+	// The directory the segments are kept in, it's created when it doesn't exist.
+	Dir string
+	// How large a segment grows before a new one is started. Defaults to 16 MiB.
+	SegmentSize int64
+	// How much space the segments may take up in total, lines are dropped once it's used up. Defaults to 256 MiB.
+	MaxBytes int64
+	// Syncs the segment to disk after every line, so lines survive the machine crashing as well.
+	Sync bool
+}
+
+const (
+	segmentExtension = ".wal"
+	checkpointFile   = "checkpoint"
+	// The length of the line and the CRC-32 of the length and the line together, both little endian uint32s.
+	recordHeaderSize = 8
+)
+
+/*
+	Creates a Buffer that writes every line ahead to append only segment files, meant to be the Buffer of a
+	NetworkSink or a HTTPSink so lines survive the endpoint and the process going down.
+
+	Every line is stored with its length and a checksum of both. How far the lines have been popped is kept in a checkpoint
+	file, so lines that were pushed but not popped before a restart are replayed when the Buffer is created again.
+	A line that was popped right before the process died may be replayed once more. Lines that don't match their
+	checksum, e.g. because the process died halfway through writing them, are dropped along with the rest of their
+	segment. Segments are deleted once every line in them has been popped.
+
+	Returns an error when the directory or the segments can't be read.
+*/
+func NewDiskBuffer(config DiskBufferConfig) (Buffer, error) {
+	if config.Dir == "" {
+		return nil, errors.New("a directory is required")
+	}
+	if config.SegmentSize <= 0 {
+		config.SegmentSize = 16 << 20
+	}
+	if config.MaxBytes <= 0 {
+		config.MaxBytes = 256 << 20
+	}
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	buffer := &diskBuffer{config: config}
+	if err := buffer.open(); err != nil {
+		buffer.Close()
+		return nil, err
+	}
+	return buffer, nil
+}
+
+type diskSegment struct {
+	id   uint64
+	path string
+	// Where the last valid record of the segment ends.
+	end int64
+	// How many records haven't been popped yet.
+	records int
+}
+
+type diskBuffer struct {
+	config   DiskBufferConfig
+	segments []*diskSegment
+	// The last segment, which lines are appended to.
+	writer *os.File
+	// The first segment, which lines are read from.
+	reader     *os.File
+	readOffset int64
+	checkpoint *os.File
+	// The size of every segment together.
+	size  int64
+	count int
+	// The line at readOffset, once Peek read it.
+	peeked     *string
+	peekedSize int64
+}
+
+// Loads the segments and the checkpoint, and opens the last segment for appending.
+func (d *diskBuffer) open() error {
+	files, err := ioutil.ReadDir(d.config.Dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), segmentExtension) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(file.Name(), segmentExtension), 10, 64)
+		if err != nil {
+			continue
+		}
+		d.segments = append(d.segments, &diskSegment{id: id, path: filepath.Join(d.config.Dir, file.Name())})
+	}
+	sort.Slice(d.segments, func(i, j int) bool { return d.segments[i].id < d.segments[j].id })
+
+	if d.checkpoint, err = os.OpenFile(filepath.Join(d.config.Dir, checkpointFile), os.O_CREATE|os.O_RDWR, 0644); err != nil {
+		return err
+	}
+	var position [16]byte
+	if n, _ := d.checkpoint.ReadAt(position[:], 0); n == len(position) {
+		id := binary.LittleEndian.Uint64(position[:8])
+		for len(d.segments) > 0 && d.segments[0].id < id {
+			_ = os.Remove(d.segments[0].path)
+			d.segments = d.segments[1:]
+		}
+		if len(d.segments) > 0 && d.segments[0].id == id {
+			d.readOffset = int64(binary.LittleEndian.Uint64(position[8:]))
+		}
+	}
+
+	for index, segment := range d.segments {
+		start := int64(0)
+		if index == 0 {
+			start = d.readOffset
+		}
+		if err := d.scan(segment, start); err != nil {
+			return err
+		}
+		d.size += segment.end
+		d.count += segment.records
+	}
+
+	if len(d.segments) == 0 {
+		return d.rotate()
+	}
+
+	last := d.segments[len(d.segments)-1]
+	if d.writer, err = os.OpenFile(last.path, os.O_RDWR, 0644); err != nil {
+		return err
+	}
+	// Cuts off a record that was only partly written.
+	if err := d.writer.Truncate(last.end); err != nil {
+		return err
+	}
+	_, err = d.writer.Seek(last.end, io.SeekStart)
+	return err
+}
+
+// Counts the valid records of a segment from start on, and finds where they end.
+func (d *diskBuffer) scan(segment *diskSegment, start int64) error {
+	file, err := os.Open(segment.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	segment.end = start
+	for {
+		_, size, err := readRecord(file, segment.end, info.Size())
+		if err != nil {
+			return nil
+		}
+		segment.end += size
+		segment.records++
+	}
+}
+
+// Reads the record at the offset, which has to end by end. Returns it along with its size including the header.
+func readRecord(file *os.File, offset, end int64) (string, int64, error) {
+	var header [recordHeaderSize]byte
+	if _, err := file.ReadAt(header[:], offset); err != nil {
+		return "", 0, err
+	}
+
+	length := binary.LittleEndian.Uint32(header[:4])
+	if int64(length) > end-offset-recordHeaderSize {
+		return "", 0, fmt.Errorf("the record at %v of %v is longer than the segment", offset, file.Name())
+	}
+	data := make([]byte, length)
+	if _, err := file.ReadAt(data, offset+recordHeaderSize); err != nil {
+		return "", 0, err
+	}
+	if recordChecksum(header[:4], data) != binary.LittleEndian.Uint32(header[4:]) {
+		return "", 0, fmt.Errorf("the record at %v of %v doesn't match its checksum", offset, file.Name())
+	}
+	return string(data), recordHeaderSize + int64(length), nil
+}
+
+// Checksums the length along with the line, so a region of zeros isn't taken for an empty line.
+func recordChecksum(length, data []byte) uint32 {
+	return crc32.Update(crc32.ChecksumIEEE(length), crc32.IEEETable, data)
+}
+
+// Starts a new segment to append to.
+func (d *diskBuffer) rotate() error {
+	id := uint64(1)
+	if len(d.segments) > 0 {
+		id = d.segments[len(d.segments)-1].id + 1
+	}
+
+	segment := &diskSegment{id: id, path: filepath.Join(d.config.Dir, fmt.Sprintf("%020d%v", id, segmentExtension))}
+	writer, err := os.OpenFile(segment.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if d.writer != nil {
+		_ = d.writer.Close()
+	}
+	d.writer = writer
+	d.segments = append(d.segments, segment)
+	return nil
+}
+
+func (d *diskBuffer) Push(line string) error {
+	size := recordHeaderSize + int64(len(line))
+	if d.size+size > d.config.MaxBytes {
+		return errors.New("the disk buffer is full")
+	}
+
+	if last := d.segments[len(d.segments)-1]; last.end > 0 && last.end+size > d.config.SegmentSize {
+		if err := d.rotate(); err != nil {
+			return err
+		}
+	}
+
+	record := make([]byte, size)
+	binary.LittleEndian.PutUint32(record[:4], uint32(len(line)))
+	binary.LittleEndian.PutUint32(record[4:8], recordChecksum(record[:4], []byte(line)))
+	copy(record[recordHeaderSize:], line)
+
+	last := d.segments[len(d.segments)-1]
+	if _, err := d.writer.Write(record); err != nil {
+		_ = d.writer.Truncate(last.end)
+		_, _ = d.writer.Seek(last.end, io.SeekStart)
+		return err
+	}
+	if d.config.Sync {
+		if err := d.writer.Sync(); err != nil {
+			return err
+		}
+	}
+
+	last.end += size
+	last.records++
+	d.size += size
+	d.count++
+	return nil
+}
+
+func (d *diskBuffer) Peek() (string, bool) {
+	if d.peeked != nil {
+		return *d.peeked, true
+	}
+	if d.count == 0 {
+		return "", false
+	}
+
+	if d.reader == nil {
+		reader, err := os.Open(d.segments[0].path)
+		if err != nil {
+			return "", false
+		}
+		d.reader = reader
+	}
+
+	line, size, err := readRecord(d.reader, d.readOffset, d.segments[0].end)
+	if err != nil {
+		return "", false
+	}
+	d.peeked = &line
+	d.peekedSize = size
+	return line, true
+}
+
+func (d *diskBuffer) Pop() error {
+	if _, ok := d.Peek(); !ok {
+		return nil
+	}
+
+	d.readOffset += d.peekedSize
+	d.peeked = nil
+	d.segments[0].records--
+	d.count--
+
+	for len(d.segments) > 1 && d.segments[0].records == 0 {
+		if d.reader != nil {
+			_ = d.reader.Close()
+			d.reader = nil
+		}
+		if err := os.Remove(d.segments[0].path); err != nil {
+			return err
+		}
+		d.size -= d.segments[0].end
+		d.segments = d.segments[1:]
+		d.readOffset = 0
+	}
+
+	// Empties the last segment once everything in it has been popped, so it doesn't count against MaxBytes.
+	if d.count == 0 {
+		last := d.segments[0]
+		if err := d.writer.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := d.writer.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		d.size -= last.end
+		last.end = 0
+		d.readOffset = 0
+	}
+
+	var position [16]byte
+	binary.LittleEndian.PutUint64(position[:8], d.segments[0].id)
+	binary.LittleEndian.PutUint64(position[8:], uint64(d.readOffset))
+	_, err := d.checkpoint.WriteAt(position[:], 0)
+	return err
+}
+
+func (d *diskBuffer) Len() int {
+	return d.count
+}
+
+func (d *diskBuffer) Close() error {
+	var err error
+	for _, file := range []*os.File{d.reader, d.writer, d.checkpoint} {
+		if file == nil {
+			continue
+		}
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright 2020 Jacob Frazier
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+ * associated documentation files (the "Software"), to deal in the Software without restriction, including
+ * without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+ * of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following
+ * conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial
+ * portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+ * INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+ * PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+ * LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT
+ * OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+package simple_logger
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+/*
+	Watches a config file and applies it to running Logger s whenever the file changes or the process receives
+	SIGHUP. The levels, severities, paddings, columns, encoder, minimum level and time zone are replaced atomically
+	through ReconfigurableLogger.Reconfigure. Everything a Config can't describe, like Hook s, Redactor s, sampling
+	and the sink, is kept as it was.
+
+	The outcome of every reload is logged through the first Logger at SuccessLevel or FailureLevel,
+	if that Logger has the level.
+*/
+type ConfigWatcher struct {
+	// The level successful reloads are logged at, INFO by default.
+	SuccessLevel string
+	// The level failed reloads are logged at, ERROR by default.
+	FailureLevel string
+
+	path    string
+	loggers []ReconfigurableLogger
+	modTime time.Time
+	size    int64
+	mutex   sync.Mutex
+	signals chan os.Signal
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// Starts watching the file at path, checking it for changes every interval. An interval of 0 or less only
+// reloads on SIGHUP. The file is not applied straight away, load it with LoadConfig to build the Logger s
+// in the first place.
+func WatchConfig(path string, interval time.Duration, loggers ...ReconfigurableLogger) (*ConfigWatcher, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &ConfigWatcher{
+		SuccessLevel: "INFO",
+		FailureLevel: "ERROR",
+		path:         path,
+		loggers:      loggers,
+		modTime:      info.ModTime(),
+		size:         info.Size(),
+		signals:      make(chan os.Signal, 1),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	signal.Notify(w.signals, syscall.SIGHUP)
+	go w.watch(interval)
+	return w, nil
+}
+
+func (w *ConfigWatcher) watch(interval time.Duration) {
+	defer close(w.done)
+
+	// Never ticks when there's no interval.
+	var ticks <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-w.signals:
+			_ = w.Reload()
+		case <-ticks:
+			info, err := os.Stat(w.path)
+			if err != nil {
+				continue
+			}
+
+			w.mutex.Lock()
+			changed := !info.ModTime().Equal(w.modTime) || info.Size() != w.size
+			w.modTime, w.size = info.ModTime(), info.Size()
+			w.mutex.Unlock()
+
+			if changed {
+				_ = w.Reload()
+			}
+		}
+	}
+}
+
+// Reads the config file and applies it to every Logger. Nothing changes for any Logger if the new
+// configuration doesn't validate for one of them.
+func (w *ConfigWatcher) Reload() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	err := w.reload()
+	if err != nil {
+		w.report(w.FailureLevel, fmt.Sprintf("failed to reload logging configuration from %v: %v", w.path, err))
+	} else {
+		w.report(w.SuccessLevel, fmt.Sprintf("reloaded logging configuration from %v", w.path))
+	}
+	return err
+}
+
+func (w *ConfigWatcher) reload() error {
+	config, err := ReadConfig(w.path)
+	if err != nil {
+		return err
+	}
+
+	fresh := NewGenericLoggerBuilder()
+	if err := config.configure(fresh); err != nil {
+		return err
+	}
+
+	// Every Logger is checked before any is changed, so they don't end up with different configurations.
+	for _, logger := range w.loggers {
+		configured, ok := logger.(configuredLogger)
+		if !ok {
+			continue
+		}
+		builder := configured.configuration()
+		config.merge(builder, fresh.clone())
+		if err := builder.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, logger := range w.loggers {
+		err := logger.Reconfigure(func(builder LoggerBuilder) {
+			if b, ok := builder.(genericLoggerBuilder); ok {
+				config.merge(b.generic(), fresh.clone())
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ConfigWatcher) report(level, message string) {
+	if len(w.loggers) == 0 {
+		return
+	}
+	if _, ok := w.loggers[0].GetLevels()[level]; ok {
+		_, _ = w.loggers[0].Log(level, message)
+	}
+}
+
+// Stops watching the file and listening for SIGHUP.
+func (w *ConfigWatcher) Close() {
+	signal.Stop(w.signals)
+	close(w.stop)
+	<-w.done
+}